@@ -0,0 +1,97 @@
+package freshdesk
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TicketActivity is an entry of the ticket's audit log, e.g. a property change
+// made by an agent or by an automation rule.
+type TicketActivity struct {
+	Actor       ActivityActor `bson:"actor" json:"actor"`
+	Content     string        `bson:"content" json:"content"`
+	SubContents []string      `bson:"sub_contents" json:"sub_contents"`
+	CreatedAt   *time.Time    `bson:"created_at" json:"created_at"`
+}
+
+type ActivityActor struct {
+	ID   int64  `bson:"id" json:"id"`
+	Name string `bson:"name" json:"name"`
+}
+
+type RespActivities struct {
+	Activities []TicketActivity `json:"activities,omitempty"`
+}
+
+type ActivitySlice []TicketActivity
+
+func (s ActivitySlice) Len() int { return len(s) }
+
+func (s ActivitySlice) Less(i, j int) bool { return timeBefore(s[i].CreatedAt, s[j].CreatedAt) }
+
+func (s ActivitySlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s ActivitySlice) Print() {
+	for _, activity := range s {
+		fmt.Println(activity.Actor.Name, activity.Content)
+	}
+}
+
+// TimelineEntry is either an activity or a conversation of a ticket.
+type TimelineEntry struct {
+	At           time.Time
+	Activity     *TicketActivity
+	Conversation *Conversation
+}
+
+func (e TimelineEntry) String() string {
+	at := e.At.Format(time.RFC3339)
+	if e.Activity != nil {
+		line := fmt.Sprintf("%s %s %s", at, e.Activity.Actor.Name, e.Activity.Content)
+		for _, sub := range e.Activity.SubContents {
+			line += "\n\t" + sub
+		}
+		return line
+	}
+	if e.Conversation != nil {
+		kind := "reply"
+		if e.Conversation.Private {
+			kind = "private note"
+		} else if e.Conversation.Incoming {
+			kind = "incoming"
+		}
+		return fmt.Sprintf("%s [%s] user %d: %s", at, kind, e.Conversation.UserID, strings.TrimSpace(e.Conversation.BodyText))
+	}
+	return at
+}
+
+// BuildTimeline interleaves activities and conversations in chronological
+// order. Entries with the same timestamp keep activities first.
+func BuildTimeline(activities ActivitySlice, conversations ConversationSlice) []TimelineEntry {
+	timeline := make([]TimelineEntry, 0, len(activities)+len(conversations))
+	for i := range activities {
+		entry := TimelineEntry{Activity: &activities[i]}
+		if activities[i].CreatedAt != nil {
+			entry.At = *activities[i].CreatedAt
+		}
+		timeline = append(timeline, entry)
+	}
+	for i := range conversations {
+		entry := TimelineEntry{Conversation: &conversations[i]}
+		if conversations[i].CreatedAt != nil {
+			entry.At = *conversations[i].CreatedAt
+		}
+		timeline = append(timeline, entry)
+	}
+	sort.SliceStable(timeline, func(i, j int) bool { return timeline[i].At.Before(timeline[j].At) })
+	return timeline
+}
+
+func timeBefore(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b != nil
+	}
+	return a.Before(*b)
+}
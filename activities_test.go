@@ -0,0 +1,87 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func minute(n int) *time.Time {
+	t := time.Date(2020, 5, 1, 9, n, 0, 0, time.UTC)
+	return &t
+}
+
+func TestBuildTimeline(t *testing.T) {
+	activities := ActivitySlice{
+		{Actor: ActivityActor{ID: 1, Name: "Jane"}, Content: "set Status as Open", CreatedAt: minute(5)},
+		{Actor: ActivityActor{ID: 2, Name: "Rule"}, Content: "set Group as Network", SubContents: []string{"set Priority as High"}, CreatedAt: minute(1)},
+		{Actor: ActivityActor{ID: 1, Name: "Jane"}, Content: "undated"},
+	}
+	conversations := ConversationSlice{
+		{ID: 20, UserID: 1, BodyText: " Looking into it. ", CreatedAt: minute(5)},
+		{ID: 21, UserID: 3, Incoming: true, BodyText: "Still down", CreatedAt: minute(3)},
+		{ID: 22, UserID: 1, Private: true, BodyText: "Switch replaced", CreatedAt: minute(9)},
+	}
+	timeline := BuildTimeline(activities, conversations)
+
+	got := []string{}
+	for _, entry := range timeline {
+		got = append(got, entry.String())
+	}
+	want := []string{
+		"0001-01-01T00:00:00Z Jane undated",
+		"2020-05-01T09:01:00Z Rule set Group as Network\n\tset Priority as High",
+		"2020-05-01T09:03:00Z [incoming] user 3: Still down",
+		"2020-05-01T09:05:00Z Jane set Status as Open",
+		"2020-05-01T09:05:00Z [reply] user 1: Looking into it.",
+		"2020-05-01T09:09:00Z [private note] user 1: Switch replaced",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("BuildTimeline() =\n%q\nwant\n%q", got, want)
+	}
+	if timeline[3].Activity != &activities[0] || timeline[4].Conversation != &conversations[0] {
+		t.Error("timeline entries do not point into the given slices")
+	}
+	if got := (TimelineEntry{At: *minute(0)}).String(); got != "2020-05-01T09:00:00Z" {
+		t.Errorf("empty entry = %q", got)
+	}
+	if got := BuildTimeline(nil, nil); len(got) != 0 {
+		t.Errorf("BuildTimeline(nil, nil) = %v", got)
+	}
+}
+
+func TestActivitySliceSort(t *testing.T) {
+	activities := ActivitySlice{
+		{Content: "b", CreatedAt: minute(2)},
+		{Content: "undated"},
+		{Content: "a", CreatedAt: minute(1)},
+	}
+	sort.Sort(activities)
+	got := []string{}
+	for _, activity := range activities {
+		got = append(got, activity.Content)
+	}
+	if want := []string{"undated", "a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sorted = %v, want %v", got, want)
+	}
+}
+
+func TestRespActivitiesDecode(t *testing.T) {
+	body := `{"activities":[{"actor":{"id":5,"name":"Jane Doe"},"content":" updated the ticket",
+		"sub_contents":["set Status as Closed"],"created_at":"2020-05-01T09:07:00Z"}]}`
+	resp := RespActivities{}
+	if err := json.Unmarshal([]byte(body), &resp); err != nil {
+		t.Fatal(err)
+	}
+	want := []TicketActivity{{
+		Actor:       ActivityActor{ID: 5, Name: "Jane Doe"},
+		Content:     " updated the ticket",
+		SubContents: []string{"set Status as Closed"},
+		CreatedAt:   minute(7),
+	}}
+	if !reflect.DeepEqual(resp.Activities, want) {
+		t.Errorf("decoded %+v, want %+v", resp.Activities, want)
+	}
+}
@@ -0,0 +1,263 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/nextlinktechnology/go-freshservice/querybuilder"
+	"github.com/nextlinktechnology/mgm/v3"
+)
+
+type AgentManager interface {
+	All() (AgentSlice, error)
+	List(AgentFilter) (AgentSlice, error)
+	View(int64) (Agent, error)
+	Create(CreateAgent) (Agent, error)
+	Update(int64, CreateAgent) (Agent, error)
+	Deactivate(int64) error
+	Reactivate(int64) (Agent, error)
+	Forget(int64) error
+}
+
+type agentManager struct {
+	client *ApiClient
+}
+
+func newAgentManager(client *ApiClient) agentManager {
+	return agentManager{
+		client,
+	}
+}
+
+type Agent struct {
+	mgm.DefaultModel                          `bson:",inline" json:"-"`
+	ID                                        int64                  `bson:"id" json:"id"`
+	FirstName                                 string                 `bson:"first_name" json:"first_name"`
+	LastName                                  string                 `bson:"last_name" json:"last_name"`
+	Occasional                                bool                   `bson:"occasional" json:"occasional"`
+	Active                                    bool                   `bson:"active" json:"active"`
+	JobTitle                                  string                 `bson:"job_title" json:"job_title"`
+	Email                                     string                 `bson:"email" json:"email"`
+	WorkPhoneNumber                           string                 `bson:"work_phone_number" json:"work_phone_number"`
+	MobilePhoneNumber                         string                 `bson:"mobile_phone_number" json:"mobile_phone_number"`
+	DepartmentIDs                             []int64                `bson:"department_ids" json:"department_ids"`
+	CanSeeAllTicketsFromAssociatedDepartments bool                   `bson:"can_see_all_tickets_from_associated_departments" json:"can_see_all_tickets_from_associated_departments"`
+	ReportingManagerID                        int64                  `bson:"reporting_manager_id" json:"reporting_manager_id"`
+	Address                                   string                 `bson:"address" json:"address"`
+	TimeZone                                  string                 `bson:"time_zone" json:"time_zone"`
+	TimeFormat                                string                 `bson:"time_format" json:"time_format"`
+	Language                                  string                 `bson:"language" json:"language"`
+	LocationID                                int64                  `bson:"location_id" json:"location_id"`
+	BackgroundInformation                     string                 `bson:"background_information" json:"background_information"`
+	ScoreboardLevelID                         int64                  `bson:"scoreboard_level_id" json:"scoreboard_level_id"`
+	MemberOf                                  []int64                `bson:"member_of" json:"member_of"`
+	ObserverOf                                []int64                `bson:"observer_of" json:"observer_of"`
+	Roles                                     []AgentRole            `bson:"roles" json:"roles"`
+	CustomFields                              map[string]interface{} `bson:"custom_fields" json:"custom_fields"`
+	HasLoggedIn                               bool                   `bson:"has_logged_in" json:"has_logged_in"`
+	LastLoginAt                               *time.Time             `bson:"last_login_at" json:"last_login_at"`
+	LastActiveAt                              *time.Time             `bson:"last_active_at" json:"last_active_at"`
+	CreatedAt                                 *time.Time             `bson:"created_at" json:"created_at"`
+	UpdatedAt                                 *time.Time             `bson:"updated_at" json:"updated_at"`
+}
+
+// AgentRole assigns a role to an agent within the given scope.
+// Groups is only used with AssignmentScopeSpecifiedGroups.
+type AgentRole struct {
+	RoleID          int64           `bson:"role_id" json:"role_id"`
+	AssignmentScope AssignmentScope `bson:"assignment_scope" json:"assignment_scope"`
+	Groups          []int64         `bson:"groups" json:"groups,omitempty"`
+}
+
+type AssignmentScope string
+
+const (
+	AssignmentScopeEntireHelpdesk  AssignmentScope = "entire_helpdesk"
+	AssignmentScopeMemberGroups    AssignmentScope = "member_groups"
+	AssignmentScopeSpecifiedGroups AssignmentScope = "specified_groups"
+	AssignmentScopeAssignedItems   AssignmentScope = "assigned_items"
+)
+
+// CreateAgent is the payload to create or update an agent. Occasional and
+// CanSeeAllTicketsFromAssociatedDepartments are only sent when non-nil, so an
+// update can turn them off with Bool(false).
+type CreateAgent struct {
+	FirstName                                 string                 `json:"first_name,omitempty"`
+	LastName                                  string                 `json:"last_name,omitempty"`
+	Occasional                                *bool                  `json:"occasional,omitempty"`
+	JobTitle                                  string                 `json:"job_title,omitempty"`
+	Email                                     string                 `json:"email,omitempty"`
+	WorkPhoneNumber                           string                 `json:"work_phone_number,omitempty"`
+	MobilePhoneNumber                         string                 `json:"mobile_phone_number,omitempty"`
+	DepartmentIDs                             []int64                `json:"department_ids,omitempty"`
+	CanSeeAllTicketsFromAssociatedDepartments *bool                  `json:"can_see_all_tickets_from_associated_departments,omitempty"`
+	ReportingManagerID                        int64                  `json:"reporting_manager_id,omitempty"`
+	Address                                   string                 `json:"address,omitempty"`
+	TimeZone                                  string                 `json:"time_zone,omitempty"`
+	TimeFormat                                string                 `json:"time_format,omitempty"`
+	Language                                  string                 `json:"language,omitempty"`
+	LocationID                                int64                  `json:"location_id,omitempty"`
+	BackgroundInformation                     string                 `json:"background_information,omitempty"`
+	ScoreboardLevelID                         int64                  `json:"scoreboard_level_id,omitempty"`
+	MemberOf                                  []int64                `json:"member_of,omitempty"`
+	ObserverOf                                []int64                `json:"observer_of,omitempty"`
+	Roles                                     []AgentRole            `json:"roles,omitempty"`
+	CustomFields                              map[string]interface{} `json:"custom_fields,omitempty"`
+}
+
+// AgentFilter narrows the agent list. Unset fields are not sent.
+type AgentFilter struct {
+	Email      string
+	Active     *bool
+	Occasional *bool
+	// UpdatedSince, if set, only lists agents updated at or after it.
+	UpdatedSince time.Time
+}
+
+// Query converts the filter into the query string understood by the agents endpoint.
+func (filter AgentFilter) Query() querybuilder.Query {
+	query := querybuilder.BuildQuery()
+	if filter.Email != "" {
+		query.Is("email", filter.Email)
+	}
+	if filter.Active != nil {
+		query.Is("active", strconv.FormatBool(*filter.Active))
+	}
+	if filter.Occasional != nil {
+		if *filter.Occasional {
+			query.Is("state", "occasional")
+		} else {
+			query.Is("state", "fulltime")
+		}
+	}
+	if !filter.UpdatedSince.IsZero() {
+		query.UpdatedSince(filter.UpdatedSince)
+	}
+	return query
+}
+
+type RespAgents struct {
+	Agents []Agent `json:"agents,omitempty"`
+}
+
+type RespAgent struct {
+	Agent Agent `json:"agent,omitempty"`
+}
+
+func (a Agent) Print() {
+	jsonb, _ := json.MarshalIndent(a, "", "    ")
+	fmt.Println(string(jsonb))
+}
+
+type AgentSlice []Agent
+
+func (s AgentSlice) Len() int { return len(s) }
+
+func (s AgentSlice) Less(i, j int) bool { return s[i].ID < s[j].ID }
+
+func (s AgentSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s AgentSlice) Print() {
+	for _, agent := range s {
+		fmt.Println(agent.Email)
+	}
+}
+
+// Find returns the agent with the given ID, e.g. to resolve Ticket.ResponderID.
+func (s AgentSlice) Find(id int64) (Agent, bool) {
+	for _, agent := range s {
+		if agent.ID == id {
+			return agent, true
+		}
+	}
+	return Agent{}, false
+}
+
+func (manager agentManager) All() (AgentSlice, error) {
+	return manager.collect(endpoints.agents.all)
+}
+
+func (manager agentManager) List(filter AgentFilter) (AgentSlice, error) {
+	return manager.collect(endpoints.agents.search(filter.Query().URLSafe()))
+}
+
+func (manager agentManager) View(id int64) (Agent, error) {
+	output := RespAgent{}
+	_, err := manager.client.get(endpoints.agents.view(id), &output)
+	if err != nil {
+		return Agent{}, err
+	}
+	return output.Agent, nil
+}
+
+func (manager agentManager) Create(agent CreateAgent) (Agent, error) {
+	output := RespAgent{}
+	jsonb, err := json.Marshal(agent)
+	if err != nil {
+		return Agent{}, err
+	}
+	err = manager.client.postJSON(endpoints.agents.create, jsonb, &output, http.StatusCreated)
+	if err != nil {
+		return Agent{}, err
+	}
+	return output.Agent, nil
+}
+
+func (manager agentManager) Update(id int64, agent CreateAgent) (Agent, error) {
+	output := RespAgent{}
+	jsonb, err := json.Marshal(agent)
+	if err != nil {
+		return Agent{}, err
+	}
+	err = manager.client.put(endpoints.agents.update(id), jsonb, &output, http.StatusOK)
+	if err != nil {
+		return Agent{}, err
+	}
+	return output.Agent, nil
+}
+
+// Deactivate turns the agent into a deactivated account, freeing up the license.
+func (manager agentManager) Deactivate(id int64) error {
+	return manager.client.delete(endpoints.agents.deactivate(id), http.StatusNoContent)
+}
+
+func (manager agentManager) Reactivate(id int64) (Agent, error) {
+	output := RespAgent{}
+	err := manager.client.put(endpoints.agents.reactivate(id), nil, &output, http.StatusOK)
+	if err != nil {
+		return Agent{}, err
+	}
+	return output.Agent, nil
+}
+
+// Forget permanently deletes the agent and the tickets they requested.
+func (manager agentManager) Forget(id int64) error {
+	return manager.client.delete(endpoints.agents.forget(id), http.StatusNoContent)
+}
+
+func (manager agentManager) collect(path string) (AgentSlice, error) {
+	resp := RespAgents{}
+	output := AgentSlice{}
+	headers, err := manager.client.get(path, &resp)
+	if err != nil {
+		return AgentSlice{}, err
+	}
+	output = append(output, resp.Agents...)
+
+	for {
+		nextLink := manager.client.getNextLink(headers)
+		if nextLink == "" {
+			break
+		}
+		nextResp := RespAgents{}
+		headers, err = manager.client.get(nextLink, &nextResp)
+		if err != nil {
+			return AgentSlice{}, err
+		}
+		output = append(output, nextResp.Agents...)
+	}
+	return output, nil
+}
@@ -0,0 +1,45 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestCreateAgentJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		agent CreateAgent
+		want  string
+	}{
+		{"empty", CreateAgent{}, `{}`},
+		{"full time", CreateAgent{Email: "jane@example.com", Occasional: Bool(false)}, `{"occasional":false,"email":"jane@example.com"}`},
+		{"occasional", CreateAgent{Occasional: Bool(true)}, `{"occasional":true}`},
+		{"own tickets only", CreateAgent{CanSeeAllTicketsFromAssociatedDepartments: Bool(false)}, `{"can_see_all_tickets_from_associated_departments":false}`},
+	}
+	for _, test := range tests {
+		jsonb, err := json.Marshal(test.agent)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(jsonb) != test.want {
+			t.Errorf("%s: %s, want %s", test.name, jsonb, test.want)
+		}
+	}
+}
+
+func TestAgentUpdate(t *testing.T) {
+	requests := []recordedRequest{}
+	client := recordingClient(&requests, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"agent": {"id": 7, "occasional": false}}`))
+	})
+	agent, err := client.Agents.Update(7, CreateAgent{Occasional: Bool(false)})
+	if err != nil || agent.ID != 7 {
+		t.Fatalf("Update() = %+v, %v", agent, err)
+	}
+	want := []recordedRequest{{http.MethodPut, "/api/v2/agents/7", "", `{"occasional":false}`}}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("sent %+v, want %+v", requests, want)
+	}
+}
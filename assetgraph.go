@@ -0,0 +1,142 @@
+package freshdesk
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// AssetGraph is a local model of the relationships between assets. Edges point
+// from the primary to the secondary asset of a relationship, so following them
+// walks downstream and following them backwards walks upstream.
+type AssetGraph struct {
+	assets     map[int64]Asset
+	types      map[int64]RelationshipType
+	downstream map[int64]RelationshipSlice
+	upstream   map[int64]RelationshipSlice
+}
+
+// ImpactedAsset is an asset reached while walking the graph. Depth is the number
+// of relationships between it and the starting asset, and Via is the last one.
+type ImpactedAsset struct {
+	DisplayID int64
+	Asset     Asset
+	Depth     int
+	Via       Relationship
+}
+
+// NewAssetGraph builds the graph. Relationships that do not link two assets are
+// ignored; assets missing from the slice still appear, identified by display ID.
+func NewAssetGraph(assets AssetSlice, relationships RelationshipSlice, types RelationshipTypeSlice) *AssetGraph {
+	graph := &AssetGraph{
+		assets:     map[int64]Asset{},
+		types:      map[int64]RelationshipType{},
+		downstream: map[int64]RelationshipSlice{},
+		upstream:   map[int64]RelationshipSlice{},
+	}
+	for _, asset := range assets {
+		graph.assets[asset.DisplayID] = asset
+	}
+	for _, relationshipType := range types {
+		graph.types[relationshipType.ID] = relationshipType
+	}
+	for _, relationship := range relationships {
+		if relationship.PrimaryType != RelationshipItemAsset || relationship.SecondaryType != RelationshipItemAsset {
+			continue
+		}
+		graph.downstream[relationship.PrimaryID] = append(graph.downstream[relationship.PrimaryID], relationship)
+		graph.upstream[relationship.SecondaryID] = append(graph.upstream[relationship.SecondaryID], relationship)
+	}
+	return graph
+}
+
+// Downstream returns every asset the given asset relates to, directly or
+// transitively, nearest first.
+func (graph *AssetGraph) Downstream(displayID int64) []ImpactedAsset {
+	return graph.walk(displayID, graph.downstream, func(r Relationship) int64 { return r.SecondaryID })
+}
+
+// Upstream returns every asset relating to the given asset, directly or
+// transitively, nearest first. These are the assets affected when it fails.
+func (graph *AssetGraph) Upstream(displayID int64) []ImpactedAsset {
+	return graph.walk(displayID, graph.upstream, func(r Relationship) int64 { return r.PrimaryID })
+}
+
+func (graph *AssetGraph) walk(start int64, edges map[int64]RelationshipSlice, next func(Relationship) int64) []ImpactedAsset {
+	impacted := []ImpactedAsset{}
+	seen := map[int64]bool{start: true}
+	queue := []ImpactedAsset{{DisplayID: start}}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, relationship := range edges[current.DisplayID] {
+			id := next(relationship)
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			item := ImpactedAsset{
+				DisplayID: id,
+				Asset:     graph.assets[id],
+				Depth:     current.Depth + 1,
+				Via:       relationship,
+			}
+			impacted = append(impacted, item)
+			queue = append(queue, item)
+		}
+	}
+	return impacted
+}
+
+// WriteDOT writes the graph in Graphviz DOT format, labelling edges with the
+// downstream relation of their relationship type.
+func (graph *AssetGraph) WriteDOT(w io.Writer) error {
+	buf := bufio.NewWriter(w)
+	fmt.Fprintln(buf, "digraph assets {")
+
+	ids := []int64{}
+	known := map[int64]bool{}
+	add := func(id int64) {
+		if !known[id] {
+			known[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for id := range graph.assets {
+		add(id)
+	}
+	for id, relationships := range graph.downstream {
+		add(id)
+		for _, relationship := range relationships {
+			add(relationship.SecondaryID)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		fmt.Fprintf(buf, "\t%d [label=%s];\n", id, dotQuote(graph.label(id)))
+	}
+	for _, id := range ids {
+		relationships := append(RelationshipSlice{}, graph.downstream[id]...)
+		sort.Sort(relationships)
+		for _, relationship := range relationships {
+			label := graph.types[relationship.RelationshipTypeID].DownstreamRelation
+			fmt.Fprintf(buf, "\t%d -> %d [label=%s];\n", relationship.PrimaryID, relationship.SecondaryID, dotQuote(label))
+		}
+	}
+	fmt.Fprintln(buf, "}")
+	return buf.Flush()
+}
+
+func (graph *AssetGraph) label(id int64) string {
+	if asset, ok := graph.assets[id]; ok && asset.Name != "" {
+		return asset.Name
+	}
+	return fmt.Sprintf("#%d", id)
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
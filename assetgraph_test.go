@@ -0,0 +1,106 @@
+package freshdesk
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func assetLink(id, from, to int64) Relationship {
+	return Relationship{
+		ID:                 id,
+		RelationshipTypeID: 1,
+		PrimaryID:          from,
+		PrimaryType:        RelationshipItemAsset,
+		SecondaryID:        to,
+		SecondaryType:      RelationshipItemAsset,
+	}
+}
+
+// testAssetGraph models an app running on two servers that share a switch,
+// with a cycle between the servers and a relationship to a requester.
+func testAssetGraph() *AssetGraph {
+	assets := AssetSlice{
+		{DisplayID: 1, Name: "Payroll app"},
+		{DisplayID: 2, Name: "Server A"},
+		{DisplayID: 3, Name: `Server "B"`},
+		{DisplayID: 4, Name: "Core switch"},
+	}
+	relationships := RelationshipSlice{
+		assetLink(10, 1, 2),
+		assetLink(11, 1, 3),
+		assetLink(12, 2, 4),
+		assetLink(13, 3, 4),
+		assetLink(14, 3, 2),
+		assetLink(15, 2, 3),
+		assetLink(16, 4, 5),
+		{ID: 17, PrimaryID: 1, PrimaryType: RelationshipItemAsset, SecondaryID: 99, SecondaryType: "requester"},
+	}
+	types := RelationshipTypeSlice{{ID: 1, DownstreamRelation: "Depends on", UpstreamRelation: "Used by"}}
+	return NewAssetGraph(assets, relationships, types)
+}
+
+type walkStep struct {
+	DisplayID int64
+	Depth     int
+	Via       int64
+}
+
+func steps(impacted []ImpactedAsset) []walkStep {
+	result := []walkStep{}
+	for _, item := range impacted {
+		result = append(result, walkStep{item.DisplayID, item.Depth, item.Via.ID})
+	}
+	return result
+}
+
+func TestAssetGraphWalk(t *testing.T) {
+	graph := testAssetGraph()
+	tests := []struct {
+		name string
+		got  []ImpactedAsset
+		want []walkStep
+	}{
+		{"downstream", graph.Downstream(1), []walkStep{{2, 1, 10}, {3, 1, 11}, {4, 2, 12}, {5, 3, 16}}},
+		{"downstream cycle", graph.Downstream(2), []walkStep{{4, 1, 12}, {3, 1, 15}, {5, 2, 16}}},
+		{"upstream", graph.Upstream(4), []walkStep{{2, 1, 12}, {3, 1, 13}, {1, 2, 10}}},
+		{"leaf", graph.Downstream(5), []walkStep{}},
+		{"unknown", graph.Upstream(42), []walkStep{}},
+	}
+	for _, test := range tests {
+		if got := steps(test.got); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s = %v, want %v", test.name, got, test.want)
+		}
+	}
+	if got := graph.Downstream(1)[0].Asset.Name; got != "Server A" {
+		t.Errorf("impacted asset name = %q", got)
+	}
+	if got := graph.Downstream(4)[0].Asset; !reflect.DeepEqual(got, Asset{}) {
+		t.Errorf("asset missing from the slice = %+v", got)
+	}
+}
+
+func TestAssetGraphWriteDOT(t *testing.T) {
+	buf := bytes.Buffer{}
+	if err := testAssetGraph().WriteDOT(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `digraph assets {
+	1 [label="Payroll app"];
+	2 [label="Server A"];
+	3 [label="Server \"B\""];
+	4 [label="Core switch"];
+	5 [label="#5"];
+	1 -> 2 [label="Depends on"];
+	1 -> 3 [label="Depends on"];
+	2 -> 4 [label="Depends on"];
+	2 -> 3 [label="Depends on"];
+	3 -> 4 [label="Depends on"];
+	3 -> 2 [label="Depends on"];
+	4 -> 5 [label="Depends on"];
+}
+`
+	if got := buf.String(); got != want {
+		t.Errorf("WriteDOT() =\n%s\nwant\n%s", got, want)
+	}
+}
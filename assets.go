@@ -0,0 +1,382 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nextlinktechnology/go-freshservice/querybuilder"
+	"github.com/nextlinktechnology/mgm/v3"
+)
+
+type AssetManager interface {
+	All() (AssetSlice, error)
+	Filter(querybuilder.Query) (AssetSlice, error)
+	UpdatedSince(time.Time) (AssetSlice, error)
+	View(int64) (Asset, error)
+	Create(CreateAsset) (Asset, error)
+	Update(int64, CreateAsset) (Asset, error)
+	Delete(int64) error
+	Restore(int64) error
+	DeleteForever(int64) error
+}
+
+type assetManager struct {
+	client *ApiClient
+}
+
+func newAssetManager(client *ApiClient) assetManager {
+	return assetManager{
+		client,
+	}
+}
+
+// Asset is a configuration item. Assets are addressed by DisplayID, not ID.
+type Asset struct {
+	mgm.DefaultModel `bson:",inline" json:"-"`
+	ID               int64      `bson:"id" json:"id"`
+	DisplayID        int64      `bson:"display_id" json:"display_id"`
+	Name             string     `bson:"name" json:"name"`
+	Description      string     `bson:"description" json:"description"`
+	AssetTypeID      int64      `bson:"asset_type_id" json:"asset_type_id"`
+	AssetTag         string     `bson:"asset_tag" json:"asset_tag"`
+	Impact           string     `bson:"impact" json:"impact"`
+	AuthorType       string     `bson:"author_type" json:"author_type"`
+	UsageType        string     `bson:"usage_type" json:"usage_type"`
+	UserID           int64      `bson:"user_id" json:"user_id"`
+	LocationID       int64      `bson:"location_id" json:"location_id"`
+	DepartmentID     int64      `bson:"department_id" json:"department_id"`
+	AgentID          int64      `bson:"agent_id" json:"agent_id"`
+	GroupID          int64      `bson:"group_id" json:"group_id"`
+	AssignedOn       *time.Time `bson:"assigned_on" json:"assigned_on"`
+	TypeFields       TypeFields `bson:"type_fields" json:"type_fields"`
+	CreatedAt        *time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt        *time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+type CreateAsset struct {
+	Name         string     `json:"name,omitempty"`
+	Description  string     `json:"description,omitempty"`
+	AssetTypeID  int64      `json:"asset_type_id,omitempty"`
+	AssetTag     string     `json:"asset_tag,omitempty"`
+	Impact       string     `json:"impact,omitempty"`
+	UsageType    string     `json:"usage_type,omitempty"`
+	UserID       int64      `json:"user_id,omitempty"`
+	LocationID   int64      `json:"location_id,omitempty"`
+	DepartmentID int64      `json:"department_id,omitempty"`
+	AgentID      int64      `json:"agent_id,omitempty"`
+	GroupID      int64      `json:"group_id,omitempty"`
+	AssignedOn   *time.Time `json:"assigned_on,omitempty"`
+	TypeFields   TypeFields `json:"type_fields,omitempty"`
+}
+
+const (
+	AssetImpactLow    = "low"
+	AssetImpactMedium = "medium"
+	AssetImpactHigh   = "high"
+)
+
+const (
+	AssetUsagePermanent = "permanent"
+	AssetUsageLoaner    = "loaner"
+)
+
+// TypeFields holds the asset type specific fields of an asset. Freshservice
+// suffixes each key with the ID of the asset type defining the field, e.g.
+// "serial_number_12000123", so the getters look fields up by their bare name.
+//
+// Hardware and Computer decode the fields of the built-in asset types. Fields
+// of other or custom asset types are read with the getters, or decoded into a
+// struct of your own with Decode.
+type TypeFields map[string]interface{}
+
+// Key returns the type_fields key of the named field defined by the asset type.
+func (f TypeFields) Key(name string, assetTypeID int64) string {
+	return fmt.Sprintf("%s_%d", name, assetTypeID)
+}
+
+// Get returns the value of the named field, whichever asset type defines it.
+func (f TypeFields) Get(name string) (interface{}, bool) {
+	if value, ok := f[name]; ok {
+		return value, true
+	}
+	for key, value := range f {
+		if !strings.HasPrefix(key, name+"_") {
+			continue
+		}
+		if _, err := strconv.ParseInt(strings.TrimPrefix(key, name+"_"), 10, 64); err == nil {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// Set stores the value of the named field defined by the asset type.
+func (f TypeFields) Set(name string, assetTypeID int64, value interface{}) {
+	f[f.Key(name, assetTypeID)] = value
+}
+
+// String returns the named field as a string. Numbers are formatted.
+func (f TypeFields) String(name string) string {
+	value, ok := f.Get(name)
+	if !ok || value == nil {
+		return ""
+	}
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Float returns the named field as a number, parsing strings if needed.
+func (f TypeFields) Float(name string) (float64, bool) {
+	value, ok := f.Get(name)
+	if !ok {
+		return 0, false
+	}
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int64:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case string:
+		parsed, err := strconv.ParseFloat(v, 64)
+		return parsed, err == nil
+	}
+	return 0, false
+}
+
+// Int returns the named field as an integer, e.g. a product or vendor ID.
+func (f TypeFields) Int(name string) (int64, bool) {
+	value, ok := f.Float(name)
+	return int64(value), ok
+}
+
+// Time returns the named date field.
+func (f TypeFields) Time(name string) (*time.Time, bool) {
+	value, ok := f.Get(name)
+	if !ok {
+		return nil, false
+	}
+	if s, ok := value.(string); ok {
+		if parsed, err := time.Parse(time.RFC3339, s); err == nil {
+			return &parsed, true
+		}
+	}
+	return nil, false
+}
+
+// Decode copies the fields into the tagged fields of the struct out points
+// to, matching keys by their bare name. It works like DecodeCustomFields, e.g.
+// with HardwareFields or ComputerFields.
+func (f TypeFields) Decode(out interface{}) error {
+	fields := map[string]interface{}{}
+	for key, value := range f {
+		fields[typeFieldName(key)] = value
+	}
+	return DecodeCustomFields(fields, out)
+}
+
+// Encode stores the tagged fields of in as fields defined by the asset type,
+// encoded like EncodeCustomFields. The map must not be nil.
+func (f TypeFields) Encode(assetTypeID int64, in interface{}) error {
+	fields, err := EncodeCustomFields(in)
+	if err != nil {
+		return err
+	}
+	for name, value := range fields {
+		f.Set(name, assetTypeID, value)
+	}
+	return nil
+}
+
+// typeFieldName strips the asset type ID suffix from a type_fields key.
+func typeFieldName(key string) string {
+	if i := strings.LastIndex(key, "_"); i > 0 {
+		if _, err := strconv.ParseInt(key[i+1:], 10, 64); err == nil {
+			return key[:i]
+		}
+	}
+	return key
+}
+
+// HardwareFields are the fields of the built-in Hardware asset type, inherited
+// by computers, servers and other devices. Zero values are left out when
+// encoding, so only the fields set are updated.
+type HardwareFields struct {
+	ProductID          int64      `freshservice:"product_id,omitempty"`
+	VendorID           int64      `freshservice:"vendor_id,omitempty"`
+	Cost               float64    `freshservice:"cost,omitempty"`
+	Salvage            float64    `freshservice:"salvage,omitempty"`
+	DepreciationID     int64      `freshservice:"depreciation_id,omitempty"`
+	SerialNumber       string     `freshservice:"serial_number,omitempty"`
+	AssetState         string     `freshservice:"asset_state,omitempty"`
+	Domain             string     `freshservice:"domain,omitempty"`
+	AcquisitionDate    *time.Time `freshservice:"acquisition_date,omitempty"`
+	WarrantyExpiryDate *time.Time `freshservice:"warranty_expiry_date,omitempty"`
+}
+
+// ComputerFields are the fields of the built-in Computer asset type, as
+// reported by discovery agents and MDM integrations. Sizes are in GB and CPU
+// speed in GHz.
+type ComputerFields struct {
+	OS            string     `freshservice:"os,omitempty"`
+	OSVersion     string     `freshservice:"os_version,omitempty"`
+	OSServicePack string     `freshservice:"os_service_pack,omitempty"`
+	Memory        float64    `freshservice:"memory,omitempty"`
+	DiskSpace     float64    `freshservice:"disk_space,omitempty"`
+	CPUSpeed      float64    `freshservice:"cpu_speed,omitempty"`
+	CPUCoreCount  int        `freshservice:"cpu_core_count,omitempty"`
+	MACAddress    string     `freshservice:"mac_address,omitempty"`
+	UUID          string     `freshservice:"uuid,omitempty"`
+	Hostname      string     `freshservice:"hostname,omitempty"`
+	IPAddress     string     `freshservice:"computer_ip_address,omitempty"`
+	LastLoginBy   string     `freshservice:"last_login_by,omitempty"`
+	LastAuditDate *time.Time `freshservice:"last_audit_date,omitempty"`
+}
+
+// Hardware decodes the fields of the Hardware asset type.
+func (f TypeFields) Hardware() (HardwareFields, error) {
+	fields := HardwareFields{}
+	err := f.Decode(&fields)
+	return fields, err
+}
+
+// Computer decodes the fields of the Computer asset type.
+func (f TypeFields) Computer() (ComputerFields, error) {
+	fields := ComputerFields{}
+	err := f.Decode(&fields)
+	return fields, err
+}
+
+type RespAssets struct {
+	Assets []Asset `json:"assets,omitempty"`
+}
+
+type RespAsset struct {
+	Asset Asset `json:"asset,omitempty"`
+}
+
+func (a Asset) Print() {
+	jsonb, _ := json.MarshalIndent(a, "", "    ")
+	fmt.Println(string(jsonb))
+}
+
+type AssetSlice []Asset
+
+func (s AssetSlice) Len() int { return len(s) }
+
+func (s AssetSlice) Less(i, j int) bool { return s[i].DisplayID < s[j].DisplayID }
+
+func (s AssetSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s AssetSlice) Print() {
+	for _, asset := range s {
+		fmt.Println(asset.Name)
+	}
+}
+
+// AssetFilterQuery builds a filter query such as "asset_state:'IN USE' AND
+// department_id:5", including type fields in the results.
+func AssetFilterQuery(expression string) querybuilder.Query {
+	query := querybuilder.BuildQuery()
+	query.Is("filter", fmt.Sprintf("\"%s\"", expression))
+	query.Is("include", "type_fields")
+	return query
+}
+
+func (manager assetManager) All() (AssetSlice, error) {
+	query := querybuilder.BuildQuery()
+	query.Is("include", "type_fields")
+	return manager.Filter(query)
+}
+
+// UpdatedSince returns the assets updated at or after the time.
+func (manager assetManager) UpdatedSince(since time.Time) (AssetSlice, error) {
+	query := querybuilder.BuildQuery()
+	query.Is("include", "type_fields")
+	query.UpdatedSince(since)
+	return manager.Filter(query)
+}
+
+func (manager assetManager) Filter(query querybuilder.Query) (AssetSlice, error) {
+	resp := RespAssets{}
+	output := AssetSlice{}
+	headers, err := manager.client.get(endpoints.assets.search(query.URLSafe()), &resp)
+	if err != nil {
+		return AssetSlice{}, err
+	}
+	output = append(output, resp.Assets...)
+
+	for {
+		nextLink := manager.client.getNextLink(headers)
+		if nextLink == "" {
+			break
+		}
+		nextResp := RespAssets{}
+		headers, err = manager.client.get(nextLink, &nextResp)
+		if err != nil {
+			return AssetSlice{}, err
+		}
+		output = append(output, nextResp.Assets...)
+	}
+	return output, nil
+}
+
+func (manager assetManager) View(displayID int64) (Asset, error) {
+	output := RespAsset{}
+	_, err := manager.client.get(endpoints.assets.view(displayID), &output)
+	if err != nil {
+		return Asset{}, err
+	}
+	return output.Asset, nil
+}
+
+func (manager assetManager) Create(asset CreateAsset) (Asset, error) {
+	output := RespAsset{}
+	jsonb, err := json.Marshal(asset)
+	if err != nil {
+		return Asset{}, err
+	}
+	err = manager.client.postJSON(endpoints.assets.create, jsonb, &output, http.StatusCreated)
+	if err != nil {
+		return Asset{}, err
+	}
+	return output.Asset, nil
+}
+
+func (manager assetManager) Update(displayID int64, asset CreateAsset) (Asset, error) {
+	output := RespAsset{}
+	jsonb, err := json.Marshal(asset)
+	if err != nil {
+		return Asset{}, err
+	}
+	err = manager.client.put(endpoints.assets.update(displayID), jsonb, &output, http.StatusOK)
+	if err != nil {
+		return Asset{}, err
+	}
+	return output.Asset, nil
+}
+
+// Delete moves the asset to the trash, from where it can be restored.
+func (manager assetManager) Delete(displayID int64) error {
+	return manager.client.delete(endpoints.assets.delete(displayID), http.StatusNoContent)
+}
+
+func (manager assetManager) Restore(displayID int64) error {
+	return manager.client.put(endpoints.assets.restore(displayID), nil, nil, http.StatusNoContent)
+}
+
+// DeleteForever permanently deletes an asset that is already in the trash.
+func (manager assetManager) DeleteForever(displayID int64) error {
+	return manager.client.put(endpoints.assets.deleteForever(displayID), nil, nil, http.StatusNoContent)
+}
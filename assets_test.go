@@ -0,0 +1,81 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTypeFieldsTyped(t *testing.T) {
+	fields := TypeFields{}
+	body := `{"product_id_12000001": 17, "cost_12000001": "1299.5", "serial_number_12000001": "C02XK",
+		"warranty_expiry_date_12000001": "2023-05-01T00:00:00Z", "asset_state_12000001": "In Use",
+		"os_12000004": "macOS", "memory_12000004": 16, "cpu_core_count_12000004": 8,
+		"computer_ip_address_12000004": "10.0.0.7", "last_audit_date_12000004": null}`
+	if err := json.Unmarshal([]byte(body), &fields); err != nil {
+		t.Fatal(err)
+	}
+	warranty := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	hardware, err := fields.Hardware()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantHardware := HardwareFields{ProductID: 17, Cost: 1299.5, SerialNumber: "C02XK", AssetState: "In Use", WarrantyExpiryDate: &warranty}
+	if !reflect.DeepEqual(hardware, wantHardware) {
+		t.Errorf("Hardware() = %+v, want %+v", hardware, wantHardware)
+	}
+
+	computer, err := fields.Computer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantComputer := ComputerFields{OS: "macOS", Memory: 16, CPUCoreCount: 8, IPAddress: "10.0.0.7"}
+	if !reflect.DeepEqual(computer, wantComputer) {
+		t.Errorf("Computer() = %+v, want %+v", computer, wantComputer)
+	}
+
+	fields["memory_12000004"] = "lots"
+	if _, err := fields.Computer(); err == nil {
+		t.Error("Computer() accepted a text memory size")
+	}
+}
+
+func TestTypeFieldsEncode(t *testing.T) {
+	fields := TypeFields{"serial_number_12000001": "OLD"}
+	acquired := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+	if err := fields.Encode(12000001, HardwareFields{SerialNumber: "C02XK", VendorID: 3, AcquisitionDate: &acquired}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fields.Encode(12000004, ComputerFields{Hostname: "jane-mbp"}); err != nil {
+		t.Fatal(err)
+	}
+	want := TypeFields{
+		"serial_number_12000001":    "C02XK",
+		"vendor_id_12000001":        int64(3),
+		"acquisition_date_12000001": "2020-05-01",
+		"hostname_12000004":         "jane-mbp",
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("Encode() = %v, want %v", fields, want)
+	}
+	if err := fields.Encode(1, "not a struct"); err == nil {
+		t.Error("Encode() accepted a string")
+	}
+}
+
+func TestTypeFieldName(t *testing.T) {
+	tests := map[string]string{
+		"serial_number_12000001": "serial_number",
+		"serial_number":          "serial_number",
+		"os":                     "os",
+		"_12":                    "_12",
+		"cost_abc":               "cost_abc",
+	}
+	for key, want := range tests {
+		if got := typeFieldName(key); got != want {
+			t.Errorf("typeFieldName(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package freshdesk
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nextlinktechnology/mgm/v3"
+)
+
+type AssetTypeManager interface {
+	All() (AssetTypeSlice, error)
+	View(int64) (AssetType, error)
+	Fields(int64) ([]AssetTypeFieldGroup, error)
+}
+
+type assetTypeManager struct {
+	client *ApiClient
+}
+
+func newAssetTypeManager(client *ApiClient) assetTypeManager {
+	return assetTypeManager{
+		client,
+	}
+}
+
+type AssetType struct {
+	mgm.DefaultModel  `bson:",inline" json:"-"`
+	ID                int64      `bson:"id" json:"id"`
+	Name              string     `bson:"name" json:"name"`
+	Description       string     `bson:"description" json:"description"`
+	ParentAssetTypeID int64      `bson:"parent_asset_type_id" json:"parent_asset_type_id"`
+	Visible           bool       `bson:"visible" json:"visible"`
+	CreatedAt         *time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt         *time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// AssetTypeFieldGroup is a section of the asset type form and the fields it contains.
+type AssetTypeFieldGroup struct {
+	ID          int64            `json:"id"`
+	FieldHeader string           `json:"field_header"`
+	Fields      []AssetTypeField `json:"fields"`
+}
+
+// AssetTypeField describes one of the type_fields of an asset. Name is the
+// bare field name; TypeFields.Key adds the defining asset type's ID.
+type AssetTypeField struct {
+	ID           int64         `json:"id"`
+	AssetTypeID  int64         `json:"asset_type_id"`
+	Name         string        `json:"name"`
+	Label        string        `json:"label"`
+	Description  string        `json:"desc"`
+	FieldType    string        `json:"field_type"`
+	Required     bool          `json:"required"`
+	DefaultField bool          `json:"default_field"`
+	Choices      []interface{} `json:"choices"`
+	CreatedAt    *time.Time    `json:"created_at"`
+	UpdatedAt    *time.Time    `json:"updated_at"`
+}
+
+type RespAssetTypes struct {
+	AssetTypes []AssetType `json:"asset_types,omitempty"`
+}
+
+type RespAssetType struct {
+	AssetType AssetType `json:"asset_type,omitempty"`
+}
+
+type RespAssetTypeFields struct {
+	AssetTypeFields []AssetTypeFieldGroup `json:"asset_type_fields,omitempty"`
+}
+
+type AssetTypeSlice []AssetType
+
+func (s AssetTypeSlice) Len() int { return len(s) }
+
+func (s AssetTypeSlice) Less(i, j int) bool { return s[i].ID < s[j].ID }
+
+func (s AssetTypeSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s AssetTypeSlice) Print() {
+	for _, assetType := range s {
+		fmt.Println(assetType.Name)
+	}
+}
+
+// Lineage returns the asset type followed by its parents up to the top level
+// type. Type fields of an asset may be defined by any of these types.
+func (s AssetTypeSlice) Lineage(id int64) AssetTypeSlice {
+	lineage := AssetTypeSlice{}
+	seen := map[int64]bool{}
+	for id != 0 && !seen[id] {
+		seen[id] = true
+		found := false
+		for _, assetType := range s {
+			if assetType.ID == id {
+				lineage = append(lineage, assetType)
+				id = assetType.ParentAssetTypeID
+				found = true
+				break
+			}
+		}
+		if !found {
+			break
+		}
+	}
+	return lineage
+}
+
+func (manager assetTypeManager) All() (AssetTypeSlice, error) {
+	resp := RespAssetTypes{}
+	output := AssetTypeSlice{}
+	headers, err := manager.client.get(endpoints.assetTypes.all, &resp)
+	if err != nil {
+		return AssetTypeSlice{}, err
+	}
+	output = append(output, resp.AssetTypes...)
+
+	for {
+		nextLink := manager.client.getNextLink(headers)
+		if nextLink == "" {
+			break
+		}
+		nextResp := RespAssetTypes{}
+		headers, err = manager.client.get(nextLink, &nextResp)
+		if err != nil {
+			return AssetTypeSlice{}, err
+		}
+		output = append(output, nextResp.AssetTypes...)
+	}
+	return output, nil
+}
+
+func (manager assetTypeManager) View(id int64) (AssetType, error) {
+	output := RespAssetType{}
+	_, err := manager.client.get(endpoints.assetTypes.view(id), &output)
+	if err != nil {
+		return AssetType{}, err
+	}
+	return output.AssetType, nil
+}
+
+// Fields returns the field schema of the asset type, grouped by form section.
+func (manager assetTypeManager) Fields(id int64) ([]AssetTypeFieldGroup, error) {
+	output := RespAssetTypeFields{}
+	_, err := manager.client.get(endpoints.assetTypes.fields(id), &output)
+	if err != nil {
+		return nil, err
+	}
+	return output.AssetTypeFields, nil
+}
@@ -0,0 +1,108 @@
+package automation
+
+import (
+	"fmt"
+
+	freshservice "github.com/nextlinktechnology/go-freshservice"
+)
+
+// Action is a change a rule makes to a matching ticket. Apply updates ticket
+// with the result, including its new updated_at, so later actions of the rule
+// see it and the engine can recognise the updates it made itself.
+type Action interface {
+	String() string
+	Apply(tickets freshservice.TicketManager, ticket *freshservice.Ticket) error
+}
+
+type setStatus struct{ status freshservice.Status }
+
+type assignGroup struct{ groupID int64 }
+
+type addTag struct{ tag string }
+
+type addNote struct{ body string }
+
+type reply struct{ body string }
+
+// SetStatus sets the status of the ticket.
+func SetStatus(status freshservice.Status) Action { return setStatus{status} }
+
+// AssignGroup assigns the ticket to the agent group.
+func AssignGroup(groupID int64) Action { return assignGroup{groupID} }
+
+// AddTag adds the tag to the ticket, unless it is already tagged.
+func AddTag(tag string) Action { return addTag{tag} }
+
+// AddNote adds a note to the ticket.
+func AddNote(body string) Action { return addNote{body} }
+
+// Reply replies to the requester of the ticket.
+func Reply(body string) Action { return reply{body} }
+
+func (action setStatus) String() string { return fmt.Sprintf("set status %s", action.status) }
+
+func (action setStatus) Apply(tickets freshservice.TicketManager, ticket *freshservice.Ticket) error {
+	if ticket.Status == action.status {
+		return nil
+	}
+	return update(tickets, ticket, freshservice.CreateTicket{Status: action.status})
+}
+
+func (action assignGroup) String() string { return fmt.Sprintf("assign group %d", action.groupID) }
+
+func (action assignGroup) Apply(tickets freshservice.TicketManager, ticket *freshservice.Ticket) error {
+	if ticket.GroupID == action.groupID {
+		return nil
+	}
+	return update(tickets, ticket, freshservice.CreateTicket{GroupID: action.groupID})
+}
+
+func (action addTag) String() string { return fmt.Sprintf("add tag %q", action.tag) }
+
+func (action addTag) Apply(tickets freshservice.TicketManager, ticket *freshservice.Ticket) error {
+	for _, tag := range ticket.Tags {
+		if tag == action.tag {
+			return nil
+		}
+	}
+	tags := append(append([]string{}, ticket.Tags...), action.tag)
+	return update(tickets, ticket, freshservice.CreateTicket{Tags: tags})
+}
+
+func (action addNote) String() string { return "add note" }
+
+func (action addNote) Apply(tickets freshservice.TicketManager, ticket *freshservice.Ticket) error {
+	if _, err := tickets.AddNote(ticket.ID, freshservice.CreateConversation{Body: action.body}); err != nil {
+		return err
+	}
+	return refresh(tickets, ticket)
+}
+
+func (action reply) String() string { return "reply" }
+
+func (action reply) Apply(tickets freshservice.TicketManager, ticket *freshservice.Ticket) error {
+	if _, err := tickets.Reply(ticket.ID, freshservice.CreateConversation{Body: action.body}); err != nil {
+		return err
+	}
+	return refresh(tickets, ticket)
+}
+
+func update(tickets freshservice.TicketManager, ticket *freshservice.Ticket, change freshservice.CreateTicket) error {
+	updated, err := tickets.Update(ticket.ID, change)
+	if err != nil {
+		return err
+	}
+	*ticket = updated
+	return nil
+}
+
+// refresh reloads the ticket after a conversation was added, since adding one
+// changes updated_at without returning the ticket.
+func refresh(tickets freshservice.TicketManager, ticket *freshservice.Ticket) error {
+	refreshed, err := tickets.View(ticket.ID)
+	if err != nil {
+		return err
+	}
+	*ticket = refreshed
+	return nil
+}
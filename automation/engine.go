@@ -0,0 +1,285 @@
+// Package automation runs local rules against Freshservice tickets, as they
+// arrive from a TicketFeed or a webhook.
+//
+// A rule pairs a condition, written as a filter expression such as
+// "status:2 AND priority:>3 AND tag:'vip'", with the actions to apply to the
+// tickets it matches. A rule runs on every update of a matching ticket, so
+// rules adding notes or replies should run once per ticket:
+//
+//	rule, err := automation.NewRule("escalate vip", "status:2 AND tag:'vip'",
+//		automation.AssignGroup(vipGroupID),
+//		automation.AddNote("Escalated to the VIP desk."))
+//	rule = rule.WithOncePerTicket().WithRateLimit(10, time.Hour)
+//	engine := automation.NewEngine(client.Tickets, rule)
+//	feed.Run(ctx, engine.HandleEvent)
+package automation
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	freshservice "github.com/nextlinktechnology/go-freshservice"
+	"github.com/nextlinktechnology/go-freshservice/querybuilder"
+	"github.com/nextlinktechnology/go-freshservice/webhook"
+)
+
+// Rule applies its actions, in order, to the tickets matching its condition.
+type Rule struct {
+	Name      string
+	Condition querybuilder.Filter
+	Actions   []Action
+	// Limit caps the executions of the rule to Limit per Period. Zero means
+	// no limit.
+	Limit  int
+	Period time.Duration
+	// OncePerTicket runs the rule at most once on each ticket, instead of on
+	// every update of a matching ticket. The engine remembers the tickets in
+	// memory only, so they are forgotten when it is recreated.
+	OncePerTicket bool
+}
+
+// NewRule parses the condition into a rule.
+func NewRule(name, condition string, actions ...Action) (Rule, error) {
+	filter, err := querybuilder.ParseFilter(condition)
+	if err != nil {
+		return Rule{}, err
+	}
+	return Rule{Name: name, Condition: filter, Actions: actions}, nil
+}
+
+// WithRateLimit returns the rule limited to limit executions per period.
+func (rule Rule) WithRateLimit(limit int, period time.Duration) Rule {
+	rule.Limit = limit
+	rule.Period = period
+	return rule
+}
+
+// WithOncePerTicket returns the rule running at most once on each ticket.
+func (rule Rule) WithOncePerTicket() Rule {
+	rule.OncePerTicket = true
+	return rule
+}
+
+// Engine evaluates its rules against tickets and records every execution in
+// Log. In DryRun mode matching rules are logged but their actions not applied.
+//
+// The engine remembers the updated_at its actions leave on a ticket and
+// ignores the ticket while it still carries one of them, so its own updates
+// coming back through the feed or a webhook do not trigger the rules again.
+type Engine struct {
+	DryRun bool
+	Log    ExecutionLog
+
+	tickets freshservice.TicketManager
+	rules   []Rule
+
+	mutex    sync.Mutex
+	runs     map[string][]time.Time
+	produced map[int64]map[string]time.Time
+	done     map[int64]map[string]bool
+}
+
+func NewEngine(tickets freshservice.TicketManager, rules ...Rule) *Engine {
+	return &Engine{
+		Log:      &MemoryLog{},
+		tickets:  tickets,
+		rules:    rules,
+		runs:     map[string][]time.Time{},
+		produced: map[int64]map[string]time.Time{},
+		done:     map[int64]map[string]bool{},
+	}
+}
+
+// Evaluate runs every rule matching the ticket, in order. The actions of a
+// rule see the ticket as updated by earlier rules. The errors of failed rules
+// are returned together once all rules ran. A rule running once per ticket
+// counts as done once its actions all succeeded, or matched in DryRun mode.
+func (engine *Engine) Evaluate(ticket freshservice.Ticket) error {
+	if engine.producedBySelf(ticket) {
+		return nil
+	}
+	failed := []string{}
+	for _, rule := range engine.rules {
+		if !rule.Condition.Match(TicketFields(ticket)) || engine.ran(rule, ticket.ID) {
+			continue
+		}
+		execution := Execution{
+			Time:     time.Now(),
+			Rule:     rule.Name,
+			TicketID: ticket.ID,
+			DryRun:   engine.DryRun,
+		}
+		if !engine.allow(rule, execution.Time) {
+			execution.RateLimited = true
+			engine.Log.Record(execution)
+			continue
+		}
+		var err error
+		for _, action := range rule.Actions {
+			execution.Actions = append(execution.Actions, action.String())
+			if engine.DryRun {
+				continue
+			}
+			updatedAt := ticket.UpdatedAt
+			err = action.Apply(engine.tickets, &ticket)
+			if ticket.UpdatedAt != nil && (updatedAt == nil || !ticket.UpdatedAt.Equal(*updatedAt)) {
+				engine.remember(ticket.ID, rule.Name, *ticket.UpdatedAt)
+			}
+			if err != nil {
+				execution.Err = err
+				failed = append(failed, rule.Name+": "+err.Error())
+				break
+			}
+		}
+		if err == nil && rule.OncePerTicket {
+			engine.markRan(rule, ticket.ID)
+		}
+		engine.Log.Record(execution)
+	}
+	if len(failed) > 0 {
+		return errors.New(strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// HandleEvent evaluates the ticket of a feed event. Only created and updated
+// events are evaluated, since every change to a ticket produces exactly one
+// of them.
+func (engine *Engine) HandleEvent(event freshservice.TicketEvent) error {
+	if event.Type != freshservice.TicketCreated && event.Type != freshservice.TicketUpdated {
+		return nil
+	}
+	return engine.Evaluate(event.Ticket)
+}
+
+// HandleWebhook evaluates the ticket of a webhook event. Conditions can only
+// use the ticket fields present in the webhook payload.
+func (engine *Engine) HandleWebhook(r *http.Request, event webhook.Event) error {
+	return engine.Evaluate(event.Ticket)
+}
+
+// ran reports whether the rule runs once per ticket and already ran on it.
+func (engine *Engine) ran(rule Rule, ticketID int64) bool {
+	if !rule.OncePerTicket {
+		return false
+	}
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+	return engine.done[ticketID][rule.Name]
+}
+
+func (engine *Engine) markRan(rule Rule, ticketID int64) {
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+	if engine.done[ticketID] == nil {
+		engine.done[ticketID] = map[string]bool{}
+	}
+	engine.done[ticketID][rule.Name] = true
+}
+
+// remember records the updated_at an action of the rule left on the ticket.
+func (engine *Engine) remember(ticketID int64, rule string, updatedAt time.Time) {
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+	if engine.produced[ticketID] == nil {
+		engine.produced[ticketID] = map[string]time.Time{}
+	}
+	engine.produced[ticketID][rule] = updatedAt
+}
+
+// producedBySelf reports whether the ticket is in a state left by the engine's
+// own actions. Once the ticket moved past them they are forgotten.
+func (engine *Engine) producedBySelf(ticket freshservice.Ticket) bool {
+	if ticket.UpdatedAt == nil {
+		return false
+	}
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+	latest := time.Time{}
+	for _, updatedAt := range engine.produced[ticket.ID] {
+		if updatedAt.Equal(*ticket.UpdatedAt) {
+			return true
+		}
+		if updatedAt.After(latest) {
+			latest = updatedAt
+		}
+	}
+	if ticket.UpdatedAt.After(latest) {
+		delete(engine.produced, ticket.ID)
+	}
+	return false
+}
+
+// allow reports whether the rule is within its rate limit and, if so, counts
+// an execution.
+func (engine *Engine) allow(rule Rule, now time.Time) bool {
+	if rule.Limit <= 0 {
+		return true
+	}
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+	runs := []time.Time{}
+	for _, run := range engine.runs[rule.Name] {
+		if now.Sub(run) < rule.Period {
+			runs = append(runs, run)
+		}
+	}
+	if len(runs) >= rule.Limit {
+		engine.runs[rule.Name] = runs
+		return false
+	}
+	engine.runs[rule.Name] = append(runs, now)
+	return true
+}
+
+// TicketFields exposes the ticket to filter expressions. Fields use the names
+// of the API, custom fields included, with "tag" matching any of the tags.
+// Unset group, responder and department IDs are null.
+func TicketFields(ticket freshservice.Ticket) map[string]interface{} {
+	fields := map[string]interface{}{}
+	for name, value := range ticket.CustomFields {
+		fields[name] = value
+	}
+	for name, value := range map[string]interface{}{
+		"id":               ticket.ID,
+		"subject":          ticket.Subject,
+		"description_text": ticket.DescriptionText,
+		"email":            ticket.Email,
+		"status":           ticket.Status,
+		"priority":         ticket.Priority,
+		"source":           ticket.Source,
+		"urgency":          ticket.Urgency,
+		"impact":           ticket.Impact,
+		"type":             ticket.Type,
+		"category":         ticket.Category,
+		"sub_category":     ticket.SubCategory,
+		"item_category":    ticket.ItemCategory,
+		"group_id":         optionalID(ticket.GroupID),
+		"responder_id":     optionalID(ticket.ResponderID),
+		"requester_id":     ticket.RequesterID,
+		"department_id":    optionalID(ticket.DepartmentID),
+		"tag":              ticket.Tags,
+		"tags":             ticket.Tags,
+		"is_escalated":     ticket.IsEscalated,
+		"fr_escalated":     ticket.FirstResponseEscalated,
+		"spam":             ticket.Spam,
+		"deleted":          ticket.Deleted,
+		"due_by":           ticket.DueBy,
+		"fr_due_by":        ticket.FirstResponseDueBy,
+		"created_at":       ticket.CreatedAt,
+		"updated_at":       ticket.UpdatedAt,
+	} {
+		fields[name] = value
+	}
+	return fields
+}
+
+func optionalID(id int64) interface{} {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
@@ -0,0 +1,355 @@
+package automation
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	freshservice "github.com/nextlinktechnology/go-freshservice"
+)
+
+// fakeTickets records the calls of the actions and stamps every change with
+// a later updated_at, like the API does.
+type fakeTickets struct {
+	freshservice.TicketManager
+	ticket freshservice.Ticket
+	calls  []string
+	fail   error
+}
+
+func (f *fakeTickets) touch() {
+	updatedAt := f.ticket.UpdatedAt.Add(time.Minute)
+	f.ticket.UpdatedAt = &updatedAt
+}
+
+func (f *fakeTickets) Update(id int64, change freshservice.CreateTicket) (freshservice.Ticket, error) {
+	f.calls = append(f.calls, "update")
+	if f.fail != nil {
+		return freshservice.Ticket{}, f.fail
+	}
+	if change.Status != 0 {
+		f.ticket.Status = change.Status
+	}
+	if change.GroupID != 0 {
+		f.ticket.GroupID = change.GroupID
+	}
+	if change.Tags != nil {
+		f.ticket.Tags = change.Tags
+	}
+	f.touch()
+	return f.ticket, nil
+}
+
+func (f *fakeTickets) AddNote(id int64, note freshservice.CreateConversation) (freshservice.Conversation, error) {
+	f.calls = append(f.calls, "note")
+	f.touch()
+	return freshservice.Conversation{Body: note.Body}, nil
+}
+
+func (f *fakeTickets) Reply(id int64, reply freshservice.CreateConversation) (freshservice.Conversation, error) {
+	f.calls = append(f.calls, "reply")
+	f.touch()
+	return freshservice.Conversation{Body: reply.Body}, nil
+}
+
+func (f *fakeTickets) View(id int64) (freshservice.Ticket, error) {
+	return f.ticket, nil
+}
+
+func newFakeTickets() *fakeTickets {
+	updatedAt := time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+	return &fakeTickets{ticket: freshservice.Ticket{
+		ID:        7,
+		Status:    freshservice.StatusOpen,
+		Priority:  freshservice.PriorityHigh,
+		Tags:      []string{"vip"},
+		CreatedAt: &updatedAt,
+		UpdatedAt: &updatedAt,
+	}}
+}
+
+func mustRule(t *testing.T, name, condition string, actions ...Action) Rule {
+	t.Helper()
+	rule, err := NewRule(name, condition, actions...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rule
+}
+
+func TestTicketFields(t *testing.T) {
+	ticket := freshservice.Ticket{
+		ID:           7,
+		Status:       freshservice.StatusOpen,
+		Priority:     freshservice.PriorityUrgent,
+		Urgency:      "3",
+		Tags:         []string{"VIP"},
+		ResponderID:  12,
+		CustomFields: map[string]interface{}{"cost_center": "CC-1", "status": "shadowed"},
+	}
+	tests := []struct {
+		expression string
+		want       bool
+	}{
+		{"status:2", true},
+		{"status:'open'", true},
+		{"status:'Pending'", false},
+		{"priority:>4", true},
+		{"urgency:3", true},
+		{"tag:'vip'", true},
+		{"tags:'vip'", true},
+		{"group_id:null", true},
+		{"department_id:null", true},
+		{"responder_id:null", false},
+		{"responder_id:12", true},
+		{"due_by:null", true},
+		{"cost_center:'cc-1'", true},
+		{"spam:false", true},
+	}
+	fields := TicketFields(ticket)
+	for _, test := range tests {
+		rule := mustRule(t, test.expression, test.expression)
+		if got := rule.Condition.Match(fields); got != test.want {
+			t.Errorf("%q.Match() = %v, want %v", test.expression, got, test.want)
+		}
+	}
+}
+
+func TestNewRuleInvalidCondition(t *testing.T) {
+	if _, err := NewRule("broken", "status:'open", SetStatus(freshservice.StatusPending)); err == nil {
+		t.Error("NewRule() accepted an unterminated string")
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	tickets := newFakeTickets()
+	log := &MemoryLog{}
+	engine := NewEngine(tickets,
+		mustRule(t, "escalate vip", "status:2 AND tag:'vip'", AssignGroup(5), AddTag("escalated"), AddNote("Escalated.")),
+		mustRule(t, "never", "status:5", Reply("Closed.")),
+	)
+	engine.Log = log
+
+	if err := engine.Evaluate(tickets.ticket); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Join(tickets.calls, ","), "update,update,note"; got != want {
+		t.Errorf("calls = %s, want %s", got, want)
+	}
+	if tickets.ticket.GroupID != 5 || len(tickets.ticket.Tags) != 2 {
+		t.Errorf("ticket = %+v", tickets.ticket)
+	}
+	executions := log.Executions()
+	if len(executions) != 1 {
+		t.Fatalf("executions = %v", executions)
+	}
+	execution := executions[0]
+	if execution.Rule != "escalate vip" || execution.TicketID != 7 || execution.Err != nil || execution.DryRun || execution.RateLimited {
+		t.Errorf("execution = %+v", execution)
+	}
+	if got, want := strings.Join(execution.Actions, ", "), `assign group 5, add tag "escalated", add note`; got != want {
+		t.Errorf("actions = %s, want %s", got, want)
+	}
+}
+
+func TestEvaluateIgnoresOwnUpdates(t *testing.T) {
+	tickets := newFakeTickets()
+	engine := NewEngine(tickets,
+		mustRule(t, "escalate vip", "status:2 AND tag:'vip'", AssignGroup(5), AddNote("Escalated.")),
+	)
+
+	if err := engine.Evaluate(tickets.ticket); err != nil {
+		t.Fatal(err)
+	}
+	// The feed reports the ticket as updated by the engine's own actions.
+	if err := engine.Evaluate(tickets.ticket); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Join(tickets.calls, ","), "update,note"; got != want {
+		t.Errorf("calls after own update = %s, want %s", got, want)
+	}
+
+	// Someone else updates the ticket: the rule runs again, but the group
+	// is already assigned.
+	tickets.touch()
+	if err := engine.Evaluate(tickets.ticket); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Join(tickets.calls, ","), "update,note,note"; got != want {
+		t.Errorf("calls after foreign update = %s, want %s", got, want)
+	}
+}
+
+func TestEvaluateOncePerTicket(t *testing.T) {
+	tickets := newFakeTickets()
+	log := &MemoryLog{}
+	engine := NewEngine(tickets,
+		mustRule(t, "escalate vip", "status:2 AND tag:'vip'", AddNote("Escalated.")).WithOncePerTicket(),
+	)
+	engine.Log = log
+
+	if err := engine.Evaluate(tickets.ticket); err != nil {
+		t.Fatal(err)
+	}
+	// The requester replies: an unrelated update of a still matching ticket.
+	tickets.touch()
+	if err := engine.Evaluate(tickets.ticket); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Join(tickets.calls, ","), "note"; got != want {
+		t.Errorf("calls = %s, want %s", got, want)
+	}
+	if len(log.Executions()) != 1 {
+		t.Errorf("executions = %+v", log.Executions())
+	}
+
+	// Other tickets still get their note.
+	other := tickets.ticket
+	other.ID = 8
+	if err := engine.Evaluate(other); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Join(tickets.calls, ","), "note,note"; got != want {
+		t.Errorf("calls after another ticket = %s, want %s", got, want)
+	}
+}
+
+func TestEvaluateOncePerTicketRetriesFailures(t *testing.T) {
+	tickets := newFakeTickets()
+	tickets.fail = errors.New("boom")
+	engine := NewEngine(tickets, mustRule(t, "pend", "status:2", SetStatus(freshservice.StatusPending)).WithOncePerTicket())
+
+	if err := engine.Evaluate(tickets.ticket); err == nil {
+		t.Fatal("Evaluate() succeeded")
+	}
+	tickets.fail = nil
+	if err := engine.Evaluate(tickets.ticket); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Join(tickets.calls, ","), "update,update"; got != want {
+		t.Errorf("calls = %s, want %s", got, want)
+	}
+}
+
+func TestActionsSkipUnchangedValues(t *testing.T) {
+	tickets := newFakeTickets()
+	tickets.ticket.GroupID = 5
+	engine := NewEngine(tickets,
+		mustRule(t, "noop", "status:2", SetStatus(freshservice.StatusOpen), AssignGroup(5), AddTag("VIP"), AddTag("vip")),
+	)
+	if err := engine.Evaluate(tickets.ticket); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Join(tickets.calls, ","), "update"; got != want {
+		t.Errorf("calls = %s, want %s", got, want)
+	}
+}
+
+func TestEvaluateDryRun(t *testing.T) {
+	tickets := newFakeTickets()
+	log := &MemoryLog{}
+	engine := NewEngine(tickets, mustRule(t, "close", "status:2", SetStatus(freshservice.StatusClosed), Reply("Done.")))
+	engine.Log = log
+	engine.DryRun = true
+
+	if err := engine.Evaluate(tickets.ticket); err != nil {
+		t.Fatal(err)
+	}
+	if len(tickets.calls) != 0 {
+		t.Errorf("dry run made calls %v", tickets.calls)
+	}
+	executions := log.Executions()
+	if len(executions) != 1 || !executions[0].DryRun || len(executions[0].Actions) != 2 {
+		t.Fatalf("executions = %+v", executions)
+	}
+	if got := executions[0].String(); !strings.Contains(got, `rule "close" on ticket 7: dry run: set status Closed, reply`) {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestEvaluateRateLimit(t *testing.T) {
+	tickets := newFakeTickets()
+	log := &MemoryLog{}
+	engine := NewEngine(tickets, mustRule(t, "note", "status:2", AddNote("Seen.")).WithRateLimit(2, time.Hour))
+	engine.Log = log
+
+	for i := 0; i < 3; i++ {
+		tickets.touch()
+		if err := engine.Evaluate(tickets.ticket); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(tickets.calls) != 2 {
+		t.Errorf("calls = %v, want 2 notes", tickets.calls)
+	}
+	executions := log.Executions()
+	if len(executions) != 3 || executions[1].RateLimited || !executions[2].RateLimited {
+		t.Errorf("executions = %+v", executions)
+	}
+
+	now := time.Now()
+	if engine.allow(engine.rules[0], now) {
+		t.Error("allow() within the period")
+	}
+	if !engine.allow(engine.rules[0], now.Add(time.Hour)) {
+		t.Error("allow() refused after the period")
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	tickets := newFakeTickets()
+	tickets.fail = errors.New("boom")
+	log := &MemoryLog{}
+	engine := NewEngine(tickets,
+		mustRule(t, "first", "status:2", SetStatus(freshservice.StatusPending), AddNote("not reached")),
+		mustRule(t, "second", "status:2", AssignGroup(3)),
+	)
+	engine.Log = log
+
+	err := engine.Evaluate(tickets.ticket)
+	if err == nil || err.Error() != "first: boom; second: boom" {
+		t.Errorf("Evaluate() error = %v", err)
+	}
+	executions := log.Executions()
+	if len(executions) != 2 || len(executions[0].Actions) != 1 || executions[0].Err == nil {
+		t.Errorf("executions = %+v", executions)
+	}
+}
+
+func TestHandleEvent(t *testing.T) {
+	tickets := newFakeTickets()
+	engine := NewEngine(tickets, mustRule(t, "note", "status:2", AddNote("Seen.")))
+
+	for _, eventType := range []freshservice.TicketEventType{freshservice.TicketStatusChanged, freshservice.TicketAssigned, freshservice.TicketClosed} {
+		if err := engine.HandleEvent(freshservice.TicketEvent{Type: eventType, Ticket: tickets.ticket}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(tickets.calls) != 0 {
+		t.Errorf("derived events evaluated: %v", tickets.calls)
+	}
+	if err := engine.HandleEvent(freshservice.TicketEvent{Type: freshservice.TicketUpdated, Ticket: tickets.ticket}); err != nil {
+		t.Fatal(err)
+	}
+	if len(tickets.calls) != 1 {
+		t.Errorf("calls = %v, want 1", tickets.calls)
+	}
+}
+
+func TestWriterLog(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	log := NewWriterLog(buffer)
+	at := time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+	log.Record(Execution{Time: at, Rule: "a", TicketID: 1, Actions: []string{"reply"}})
+	log.Record(Execution{Time: at, Rule: "b", TicketID: 2, RateLimited: true})
+	log.Record(Execution{Time: at, Rule: "c", TicketID: 3, Actions: []string{"add note"}, Err: errors.New("boom")})
+	want := `2020-05-01T12:00:00Z rule "a" on ticket 1: reply
+2020-05-01T12:00:00Z rule "b" on ticket 2: skipped, rate limited
+2020-05-01T12:00:00Z rule "c" on ticket 3: add note: boom
+`
+	if buffer.String() != want {
+		t.Errorf("log = %q, want %q", buffer.String(), want)
+	}
+}
@@ -0,0 +1,76 @@
+package automation
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Execution records a rule matching a ticket and what was done about it.
+type Execution struct {
+	Time     time.Time
+	Rule     string
+	TicketID int64
+	// Actions lists the actions applied, or that would have been applied in
+	// dry-run mode. On failure it ends with the failed action.
+	Actions     []string
+	DryRun      bool
+	RateLimited bool
+	Err         error
+}
+
+func (execution Execution) String() string {
+	outcome := strings.Join(execution.Actions, ", ")
+	switch {
+	case execution.RateLimited:
+		outcome = "skipped, rate limited"
+	case execution.Err != nil:
+		outcome += ": " + execution.Err.Error()
+	case execution.DryRun:
+		outcome = "dry run: " + outcome
+	}
+	return fmt.Sprintf("%s rule %q on ticket %d: %s",
+		execution.Time.Format(time.RFC3339), execution.Rule, execution.TicketID, outcome)
+}
+
+// ExecutionLog receives every execution of an Engine.
+type ExecutionLog interface {
+	Record(Execution)
+}
+
+// MemoryLog keeps executions in memory.
+type MemoryLog struct {
+	mutex      sync.Mutex
+	executions []Execution
+}
+
+func (log *MemoryLog) Record(execution Execution) {
+	log.mutex.Lock()
+	defer log.mutex.Unlock()
+	log.executions = append(log.executions, execution)
+}
+
+// Executions returns the recorded executions, oldest first.
+func (log *MemoryLog) Executions() []Execution {
+	log.mutex.Lock()
+	defer log.mutex.Unlock()
+	return append([]Execution{}, log.executions...)
+}
+
+// WriterLog writes one line per execution.
+type WriterLog struct {
+	mutex  sync.Mutex
+	writer io.Writer
+}
+
+func NewWriterLog(writer io.Writer) *WriterLog {
+	return &WriterLog{writer: writer}
+}
+
+func (log *WriterLog) Record(execution Execution) {
+	log.mutex.Lock()
+	defer log.mutex.Unlock()
+	fmt.Fprintln(log.writer, execution)
+}
@@ -0,0 +1,210 @@
+package freshdesk
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const icsTimeFormat = "20060102T150405Z"
+
+// ChangeCalendar renders planned changes and change windows as an RFC 5545
+// calendar that calendar clients can subscribe to.
+//
+// Every event keeps the same UID across exports, and its SEQUENCE grows with
+// the change's or window's UpdatedAt, so a rescheduled change replaces the old
+// event instead of appearing twice.
+//
+// Changes pending release or review are CONFIRMED and earlier ones TENTATIVE.
+// Closed changes carry no STATUS, since Freshservice does not tell whether
+// they were implemented or rejected.
+type ChangeCalendar struct {
+	// Name is shown by calendar clients as the calendar title.
+	Name string
+	// Domain is the Freshservice account domain, used for UIDs and links.
+	Domain  string
+	Changes ChangeSlice
+	Windows []CalendarWindow
+	// Now stamps events that have neither an update nor a creation time.
+	// The zero value uses the current time.
+	Now time.Time
+}
+
+// CalendarWindow is a maintenance or blackout window of the change calendar.
+// Freshservice does not expose windows through the API, so callers supply them.
+type CalendarWindow struct {
+	ID          int64
+	Name        string
+	Description string
+	Blackout    bool
+	Start       time.Time
+	End         time.Time
+	// UpdatedAt gives the event's SEQUENCE; it stays 0 when unset.
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+}
+
+// WriteICS writes the calendar. Changes without a planned start date are skipped.
+func (calendar ChangeCalendar) WriteICS(w io.Writer) error {
+	buf := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeICSLine(buf, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//nextlinktechnology//go-freshservice//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if calendar.Name != "" {
+		line("X-WR-CALNAME", icsEscape(calendar.Name))
+	}
+
+	changes := append(ChangeSlice{}, calendar.Changes...)
+	sort.Sort(changes)
+	for _, change := range changes {
+		if change.PlannedStartDate == nil {
+			continue
+		}
+		line("BEGIN", "VEVENT")
+		line("UID", fmt.Sprintf("change-%d@%s.freshservice.com", change.ID, calendar.Domain))
+		line("DTSTAMP", icsTime(calendar.stamp(change.UpdatedAt, change.CreatedAt)))
+		line("SEQUENCE", fmt.Sprint(icsSequence(change.UpdatedAt)))
+		if change.UpdatedAt != nil {
+			line("LAST-MODIFIED", icsTime(*change.UpdatedAt))
+		}
+		line("DTSTART", icsTime(*change.PlannedStartDate))
+		if change.PlannedEndDate != nil && change.PlannedEndDate.After(*change.PlannedStartDate) {
+			line("DTEND", icsTime(*change.PlannedEndDate))
+		}
+		line("SUMMARY", icsEscape(fmt.Sprintf("[CHG-%d] %s", change.ID, change.Subject)))
+		if description := calendar.describe(change); description != "" {
+			line("DESCRIPTION", icsEscape(description))
+		}
+		line("URL", fmt.Sprintf("https://%s.freshservice.com/changes/%d", calendar.Domain, change.ID))
+		line("CATEGORIES", "Change")
+		switch {
+		case change.Status == ChangeStatusClosed.Value():
+		case change.Status >= ChangeStatusPendingRelease.Value():
+			line("STATUS", "CONFIRMED")
+		default:
+			line("STATUS", "TENTATIVE")
+		}
+		line("END", "VEVENT")
+	}
+
+	for _, window := range calendar.Windows {
+		kind, category := "maintenance-window", "Maintenance window"
+		if window.Blackout {
+			kind, category = "blackout-window", "Blackout window"
+		}
+		line("BEGIN", "VEVENT")
+		line("UID", fmt.Sprintf("%s-%d@%s.freshservice.com", kind, window.ID, calendar.Domain))
+		line("DTSTAMP", icsTime(calendar.stamp(window.UpdatedAt, window.CreatedAt)))
+		line("SEQUENCE", fmt.Sprint(icsSequence(window.UpdatedAt)))
+		if window.UpdatedAt != nil {
+			line("LAST-MODIFIED", icsTime(*window.UpdatedAt))
+		}
+		line("DTSTART", icsTime(window.Start))
+		if window.End.After(window.Start) {
+			line("DTEND", icsTime(window.End))
+		}
+		line("SUMMARY", icsEscape(fmt.Sprintf("%s: %s", category, window.Name)))
+		if window.Description != "" {
+			line("DESCRIPTION", icsEscape(window.Description))
+		}
+		line("CATEGORIES", icsEscape(category))
+		line("TRANSP", "TRANSPARENT")
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+	return buf.Flush()
+}
+
+func (calendar ChangeCalendar) describe(change Change) string {
+	parts := []string{}
+	if change.DescriptionText != "" {
+		parts = append(parts, change.DescriptionText)
+	}
+	for _, window := range calendar.Windows {
+		if window.Blackout && change.BlackoutWindow != nil && change.BlackoutWindow.ID == window.ID {
+			parts = append(parts, "Blackout window: "+window.Name)
+		}
+		if !window.Blackout && change.MaintenanceWindow != nil && change.MaintenanceWindow.ID == window.ID {
+			parts = append(parts, "Maintenance window: "+window.Name)
+		}
+	}
+	plans := []struct {
+		title string
+		field *PlanningField
+	}{
+		{"Reason for change", change.PlanningFields.ReasonForChange},
+		{"Impact", change.PlanningFields.ChangeImpact},
+		{"Rollout plan", change.PlanningFields.RolloutPlan},
+		{"Backout plan", change.PlanningFields.BackoutPlan},
+	}
+	for _, plan := range plans {
+		if plan.field != nil && plan.field.DescriptionText != "" {
+			parts = append(parts, plan.title+":\n"+plan.field.DescriptionText)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// icsSequenceEpoch keeps sequences within the 32-bit INTEGER range of
+// RFC 5545 until 2088.
+var icsSequenceEpoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// icsSequence is the number of seconds between icsSequenceEpoch and the last
+// update, which only grows as the change or window is edited. It does not
+// depend on the creation time, which Freshservice may report differently
+// between exports.
+func icsSequence(updatedAt *time.Time) int64 {
+	if updatedAt == nil || updatedAt.Before(icsSequenceEpoch) {
+		return 0
+	}
+	return int64(updatedAt.Sub(icsSequenceEpoch) / time.Second)
+}
+
+func (calendar ChangeCalendar) stamp(times ...*time.Time) time.Time {
+	for _, t := range times {
+		if t != nil {
+			return *t
+		}
+	}
+	if calendar.Now.IsZero() {
+		return time.Now()
+	}
+	return calendar.Now
+}
+
+func icsTime(t time.Time) string {
+	return t.UTC().Format(icsTimeFormat)
+}
+
+func icsEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// writeICSLine writes a content line folded at 75 octets, never splitting a
+// UTF-8 sequence, terminated by CRLF.
+func writeICSLine(w *bufio.Writer, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut])
+		w.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74
+	}
+	w.WriteString(line)
+	w.WriteString("\r\n")
+}
@@ -0,0 +1,165 @@
+package freshdesk
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func calendarTime(value string) *time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		panic(err)
+	}
+	return &t
+}
+
+func testChangeCalendar() ChangeCalendar {
+	created := calendarTime("2020-04-01T08:00:00Z")
+	return ChangeCalendar{
+		Name:   "Planned changes",
+		Domain: "acme",
+		Changes: ChangeSlice{
+			{
+				ID:                12,
+				Subject:           "Replace core switch; reroute VLANs, then verify uplinks on every floor of the Ghent office",
+				DescriptionText:   "Downtime expected.\nCall the NOC if it runs late.",
+				Status:            ChangeStatusPendingRelease.Value(),
+				PlannedStartDate:  calendarTime("2020-05-02T22:00:00Z"),
+				PlannedEndDate:    calendarTime("2020-05-03T02:00:00Z"),
+				MaintenanceWindow: &ChangeWindow{ID: 1},
+				PlanningFields: ChangePlanningFields{
+					BackoutPlan: &PlanningField{DescriptionText: "Reinstall the old switch."},
+				},
+				CreatedAt: created,
+				UpdatedAt: calendarTime("2020-04-01T09:00:00Z"),
+			},
+			{
+				ID:               11,
+				Subject:          "Patch mail servers — café wifi",
+				Status:           ChangeStatusPlanning.Value(),
+				PlannedStartDate: calendarTime("2020-05-04T18:00:00Z"),
+				PlannedEndDate:   calendarTime("2020-05-04T18:00:00Z"),
+				CreatedAt:        created,
+			},
+			{
+				ID:               10,
+				Subject:          "Retire fax server",
+				Status:           ChangeStatusClosed.Value(),
+				PlannedStartDate: calendarTime("2020-04-20T10:00:00Z"),
+				PlannedEndDate:   calendarTime("2020-04-20T11:00:00Z"),
+				CreatedAt:        created,
+				UpdatedAt:        calendarTime("2020-04-21T10:00:00Z"),
+			},
+			{ID: 13, Subject: "Not scheduled yet", CreatedAt: created},
+		},
+		Windows: []CalendarWindow{
+			{
+				ID:          1,
+				Name:        "Weekend maintenance",
+				Description: "Saturday night, 22:00-04:00",
+				Start:       *calendarTime("2020-05-02T22:00:00Z"),
+				End:         *calendarTime("2020-05-03T04:00:00Z"),
+				CreatedAt:   created,
+				UpdatedAt:   calendarTime("2020-04-02T08:00:00Z"),
+			},
+			{
+				ID:        2,
+				Name:      "Quarter close",
+				Blackout:  true,
+				Start:     *calendarTime("2020-06-25T00:00:00Z"),
+				End:       *calendarTime("2020-07-01T00:00:00Z"),
+				CreatedAt: created,
+			},
+		},
+	}
+}
+
+func writeCalendar(t *testing.T, calendar ChangeCalendar) string {
+	t.Helper()
+	buf := bytes.Buffer{}
+	if err := calendar.WriteICS(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestChangeCalendarGolden(t *testing.T) {
+	got := writeCalendar(t, testChangeCalendar())
+	golden := filepath.Join("testdata", "changecalendar.ics")
+	if *update {
+		if err := ioutil.WriteFile(golden, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != string(want) {
+		t.Errorf("calendar differs from %s (run with -update to rewrite it):\n%s", golden, got)
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(got, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line longer than 75 octets: %q", line)
+		}
+	}
+}
+
+// TestChangeCalendarReschedule checks that rescheduling keeps the UIDs and
+// raises the SEQUENCE of changes and windows alike.
+func TestChangeCalendarReschedule(t *testing.T) {
+	calendar := testChangeCalendar()
+	before := writeCalendar(t, calendar)
+
+	calendar.Changes[0].PlannedStartDate = calendarTime("2020-05-09T22:00:00Z")
+	calendar.Changes[0].UpdatedAt = calendarTime("2020-04-03T09:00:00Z")
+	calendar.Windows[0].Start = *calendarTime("2020-05-09T22:00:00Z")
+	calendar.Windows[0].UpdatedAt = calendarTime("2020-04-03T08:00:00Z")
+	after := writeCalendar(t, calendar)
+
+	for _, uid := range []string{"change-12@acme.freshservice.com", "maintenance-window-1@acme.freshservice.com"} {
+		if strings.Count(after, "UID:"+uid) != 1 {
+			t.Errorf("UID %s not exported once", uid)
+		}
+	}
+	tests := []struct{ before, after string }{
+		{"SEQUENCE:7894800", "SEQUENCE:8067600"},
+		{"SEQUENCE:7977600", "SEQUENCE:8064000"},
+	}
+	for _, test := range tests {
+		if !strings.Contains(before, test.before) || !strings.Contains(after, test.after) {
+			t.Errorf("sequence did not go from %s to %s", test.before, test.after)
+		}
+	}
+}
+
+// TestChangeCalendarStamp checks that the SEQUENCE ignores the creation time
+// and that events without any times are stamped with the calendar's Now.
+func TestChangeCalendarStamp(t *testing.T) {
+	calendar := testChangeCalendar()
+	before := writeCalendar(t, calendar)
+	for i := range calendar.Changes {
+		calendar.Changes[i].CreatedAt = calendarTime("2020-04-01T00:00:00Z")
+	}
+	if after := writeCalendar(t, calendar); strings.Count(after, "SEQUENCE:7894800") != 1 ||
+		strings.Count(before, "SEQUENCE:") != strings.Count(after, "SEQUENCE:") {
+		t.Error("changing the creation time changed the sequences")
+	}
+
+	calendar.Now = *calendarTime("2020-05-01T12:00:00Z")
+	calendar.Changes = nil
+	calendar.Windows = []CalendarWindow{{ID: 3, Name: "Undated", Start: *calendarTime("2020-06-01T00:00:00Z")}}
+	got := writeCalendar(t, calendar)
+	for _, want := range []string{"DTSTAMP:20200501T120000Z\r\n", "SEQUENCE:0\r\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("undated window lacks %q:\n%s", want, got)
+		}
+	}
+}
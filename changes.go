@@ -0,0 +1,316 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/nextlinktechnology/go-freshservice/querybuilder"
+	"github.com/nextlinktechnology/mgm/v3"
+)
+
+type ChangeManager interface {
+	All() (ChangeResults, error)
+	Filter(querybuilder.Query) (ChangeResults, error)
+	View(int64) (Change, error)
+	Create(CreateChange) (Change, error)
+	Update(int64, CreateChange) (Change, error)
+	Delete(int64) error
+	Notes(int64) (ConversationSlice, error)
+	AddNote(int64, CreateConversation) (Conversation, error)
+	Tasks(int64) (TaskSlice, error)
+	CreateTask(int64, CreateTask) (Task, error)
+	UpdateTask(int64, int64, CreateTask) (Task, error)
+	DeleteTask(int64, int64) error
+	TimeEntries(int64) (TimeEntrySlice, error)
+	CreateTimeEntry(int64, CreateTimeEntry) (TimeEntry, error)
+	UpdateTimeEntry(int64, int64, CreateTimeEntry) (TimeEntry, error)
+	DeleteTimeEntry(int64, int64) error
+	AssociateTickets(int64, ...int64) error
+}
+
+type changeManager struct {
+	client *ApiClient
+}
+
+type ChangeResults struct {
+	NextURL string      `json:"next_url"`
+	Results ChangeSlice `json:"results"`
+	client  *ApiClient
+}
+
+func newChangeManager(client *ApiClient) changeManager {
+	return changeManager{
+		client,
+	}
+}
+
+type Change struct {
+	mgm.DefaultModel  `bson:",inline" json:"-"`
+	ID                int64                  `bson:"id" json:"id"`
+	RequesterID       int64                  `bson:"requester_id" json:"requester_id"`
+	AgentID           int64                  `bson:"agent_id" json:"agent_id"`
+	GroupID           int64                  `bson:"group_id" json:"group_id"`
+	DepartmentID      int64                  `bson:"department_id" json:"department_id"`
+	Subject           string                 `bson:"subject" json:"subject"`
+	Description       string                 `bson:"description" json:"description"`
+	DescriptionText   string                 `bson:"description_text" json:"description_text"`
+	Priority          int                    `bson:"priority" json:"priority"`
+	Impact            int                    `bson:"impact" json:"impact"`
+	Status            int                    `bson:"status" json:"status"`
+	Risk              int                    `bson:"risk" json:"risk"`
+	ChangeType        int                    `bson:"change_type" json:"change_type"`
+	ApprovalStatus    int                    `bson:"approval_status" json:"approval_status"`
+	PlannedStartDate  *time.Time             `bson:"planned_start_date" json:"planned_start_date"`
+	PlannedEndDate    *time.Time             `bson:"planned_end_date" json:"planned_end_date"`
+	Category          string                 `bson:"category" json:"category"`
+	SubCategory       string                 `bson:"sub_category" json:"sub_category"`
+	ItemCategory      string                 `bson:"item_category" json:"item_category"`
+	MaintenanceWindow *ChangeWindow          `bson:"maintenance_window" json:"maintenance_window"`
+	BlackoutWindow    *ChangeWindow          `bson:"blackout_window" json:"blackout_window"`
+	PlanningFields    ChangePlanningFields   `bson:"planning_fields" json:"planning_fields"`
+	Attachments       []interface{}          `bson:"attachments" json:"attachments"`
+	CustomFields      map[string]interface{} `bson:"custom_fields" json:"custom_fields"`
+	CreatedAt         *time.Time             `bson:"created_at" json:"created_at"`
+	UpdatedAt         *time.Time             `bson:"updated_at" json:"updated_at"`
+}
+
+// ChangeWindow references a maintenance or blackout window of the change calendar.
+type ChangeWindow struct {
+	ID int64 `bson:"id" json:"id"`
+}
+
+// ChangePlanningFields holds the planning section of a change.
+type ChangePlanningFields struct {
+	ReasonForChange *PlanningField `bson:"reason_for_change" json:"reason_for_change,omitempty"`
+	ChangeImpact    *PlanningField `bson:"change_impact" json:"change_impact,omitempty"`
+	RolloutPlan     *PlanningField `bson:"rollout_plan" json:"rollout_plan,omitempty"`
+	BackoutPlan     *PlanningField `bson:"backout_plan" json:"backout_plan,omitempty"`
+}
+
+type PlanningField struct {
+	Description     string `bson:"description" json:"description"`
+	DescriptionText string `bson:"description_text" json:"description_text,omitempty"`
+}
+
+type CreateChange struct {
+	RequesterID       int64                  `json:"requester_id,omitempty"`
+	Email             string                 `json:"email,omitempty"`
+	AgentID           int64                  `json:"agent_id,omitempty"`
+	GroupID           int64                  `json:"group_id,omitempty"`
+	DepartmentID      int64                  `json:"department_id,omitempty"`
+	Subject           string                 `json:"subject,omitempty"`
+	Description       string                 `json:"description,omitempty"`
+	Priority          int                    `json:"priority,omitempty"`
+	Impact            int                    `json:"impact,omitempty"`
+	Status            int                    `json:"status,omitempty"`
+	Risk              int                    `json:"risk,omitempty"`
+	ChangeType        int                    `json:"change_type,omitempty"`
+	PlannedStartDate  *time.Time             `json:"planned_start_date,omitempty"`
+	PlannedEndDate    *time.Time             `json:"planned_end_date,omitempty"`
+	Category          string                 `json:"category,omitempty"`
+	SubCategory       string                 `json:"sub_category,omitempty"`
+	ItemCategory      string                 `json:"item_category,omitempty"`
+	MaintenanceWindow *ChangeWindow          `json:"maintenance_window,omitempty"`
+	BlackoutWindow    *ChangeWindow          `json:"blackout_window,omitempty"`
+	PlanningFields    *ChangePlanningFields  `json:"planning_fields,omitempty"`
+	Attachments       []interface{}          `json:"attachments,omitempty"`
+	CustomFields      map[string]interface{} `json:"custom_fields,omitempty"`
+}
+
+type ChangeStatus int
+type ChangeType int
+type ChangeRisk int
+
+const (
+	ChangeStatusOpen ChangeStatus = 1 + iota
+	ChangeStatusPlanning
+	ChangeStatusAwaitingApproval
+	ChangeStatusPendingRelease
+	ChangeStatusPendingReview
+	ChangeStatusClosed
+)
+
+const (
+	ChangeTypeMinor ChangeType = 1 + iota
+	ChangeTypeStandard
+	ChangeTypeMajor
+	ChangeTypeEmergency
+)
+
+const (
+	ChangeRiskLow ChangeRisk = 1 + iota
+	ChangeRiskMedium
+	ChangeRiskHigh
+	ChangeRiskVeryHigh
+)
+
+func (s ChangeStatus) Value() int {
+	return int(s)
+}
+
+func (t ChangeType) Value() int {
+	return int(t)
+}
+
+func (r ChangeRisk) Value() int {
+	return int(r)
+}
+
+type RespChanges struct {
+	Changes []Change `json:"changes,omitempty"`
+}
+
+type RespChange struct {
+	Change Change `json:"change,omitempty"`
+}
+
+func (c Change) Print() {
+	jsonb, _ := json.MarshalIndent(c, "", "    ")
+	fmt.Println(string(jsonb))
+}
+
+type ChangeSlice []Change
+
+func (s ChangeSlice) Len() int { return len(s) }
+
+func (s ChangeSlice) Less(i, j int) bool { return s[i].ID < s[j].ID }
+
+func (s ChangeSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s ChangeSlice) Print() {
+	for _, change := range s {
+		fmt.Println(change.Subject)
+	}
+}
+
+// ChangeFilterQuery builds a filter query such as "status:3 AND priority:4".
+func ChangeFilterQuery(expression string) querybuilder.Query {
+	query := querybuilder.BuildQuery()
+	query.Is("query", fmt.Sprintf("\"%s\"", expression))
+	return query
+}
+
+func (manager changeManager) All() (ChangeResults, error) {
+	return manager.results(endpoints.changes.all)
+}
+
+func (manager changeManager) Filter(query querybuilder.Query) (ChangeResults, error) {
+	return manager.results(endpoints.changes.filter(query.URLSafe()))
+}
+
+func (manager changeManager) View(id int64) (Change, error) {
+	output := RespChange{}
+	_, err := manager.client.get(endpoints.changes.view(id), &output)
+	if err != nil {
+		return Change{}, err
+	}
+	return output.Change, nil
+}
+
+func (manager changeManager) Create(change CreateChange) (Change, error) {
+	output := RespChange{}
+	jsonb, err := json.Marshal(change)
+	if err != nil {
+		return Change{}, err
+	}
+	err = manager.client.postJSON(endpoints.changes.create, jsonb, &output, http.StatusCreated)
+	if err != nil {
+		return Change{}, err
+	}
+	return output.Change, nil
+}
+
+func (manager changeManager) Update(id int64, change CreateChange) (Change, error) {
+	output := RespChange{}
+	jsonb, err := json.Marshal(change)
+	if err != nil {
+		return Change{}, err
+	}
+	err = manager.client.put(endpoints.changes.update(id), jsonb, &output, http.StatusOK)
+	if err != nil {
+		return Change{}, err
+	}
+	return output.Change, nil
+}
+
+func (manager changeManager) Delete(id int64) error {
+	return manager.client.delete(endpoints.changes.delete(id), http.StatusNoContent)
+}
+
+func (manager changeManager) Notes(id int64) (ConversationSlice, error) {
+	return manager.client.getNotes(endpoints.changes.notes(id))
+}
+
+func (manager changeManager) AddNote(id int64, note CreateConversation) (Conversation, error) {
+	return manager.client.postNote(endpoints.changes.notes(id), note)
+}
+
+func (manager changeManager) Tasks(id int64) (TaskSlice, error) {
+	return manager.client.getTasks(endpoints.changes.tasks(id))
+}
+
+func (manager changeManager) CreateTask(id int64, task CreateTask) (Task, error) {
+	return manager.client.postTask(endpoints.changes.tasks(id), task)
+}
+
+func (manager changeManager) UpdateTask(id, taskID int64, task CreateTask) (Task, error) {
+	return manager.client.putTask(endpoints.changes.task(id, taskID), task)
+}
+
+func (manager changeManager) DeleteTask(id, taskID int64) error {
+	return manager.client.delete(endpoints.changes.task(id, taskID), http.StatusNoContent)
+}
+
+func (manager changeManager) TimeEntries(id int64) (TimeEntrySlice, error) {
+	return manager.client.getTimeEntries(endpoints.changes.timeEntries(id))
+}
+
+func (manager changeManager) CreateTimeEntry(id int64, entry CreateTimeEntry) (TimeEntry, error) {
+	return manager.client.postTimeEntry(endpoints.changes.timeEntries(id), entry)
+}
+
+func (manager changeManager) UpdateTimeEntry(id, entryID int64, entry CreateTimeEntry) (TimeEntry, error) {
+	return manager.client.putTimeEntry(endpoints.changes.timeEntry(id, entryID), entry)
+}
+
+func (manager changeManager) DeleteTimeEntry(id, entryID int64) error {
+	return manager.client.delete(endpoints.changes.timeEntry(id, entryID), http.StatusNoContent)
+}
+
+func (manager changeManager) results(path string) (ChangeResults, error) {
+	resp := RespChanges{}
+	output := ChangeSlice{}
+	headers, err := manager.client.get(path, &resp)
+	if err != nil {
+		return ChangeResults{}, err
+	}
+	output = append(output, resp.Changes...)
+
+	return ChangeResults{
+		Results: output,
+		client:  manager.client,
+		NextURL: manager.client.getNextLink(headers),
+	}, nil
+}
+
+func (results ChangeResults) Next() (ChangeResults, error) {
+	if results.NextURL == "" {
+		return ChangeResults{}, errors.New("no more changes")
+	}
+	return changeManager{results.client}.results(results.NextURL)
+}
+
+// AssociateTickets links each ticket to the change. It stops at the first
+// ticket that cannot be updated.
+func (manager changeManager) AssociateTickets(id int64, ticketIDs ...int64) error {
+	tickets := newTicketManager(manager.client)
+	for _, ticketID := range ticketIDs {
+		if _, err := tickets.AssociateChange(ticketID, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,259 @@
+package freshdesk
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CustomFieldTag is the struct tag naming the custom field a struct field maps
+// to, e.g.
+//
+//	type Onboarding struct {
+//		Laptop    bool       `freshservice:"cf_needs_laptop"`
+//		StartDate *time.Time `freshservice:"cf_start_date,omitempty"`
+//		Cost      float64    `freshservice:"cf_cost"`
+//	}
+//
+// Fields without the tag are ignored. With omitempty, zero values are left
+// out when encoding; otherwise they are sent, clearing the field in Freshservice.
+const CustomFieldTag = "freshservice"
+
+// customFieldDateFormat is the format Freshservice uses for date custom fields.
+const customFieldDateFormat = "2006-01-02"
+
+// CustomFieldError describes a custom field value that does not fit the
+// struct field it is decoded into.
+type CustomFieldError struct {
+	Field string
+	Value interface{}
+	Type  reflect.Type
+}
+
+func (e CustomFieldError) Error() string {
+	return fmt.Sprintf("custom field %s: cannot decode %#v into %s", e.Field, e.Value, e.Type)
+}
+
+// CustomFieldErrors collects every mismatch found while decoding.
+type CustomFieldErrors []CustomFieldError
+
+func (e CustomFieldErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// DecodeCustomFields copies custom field values into the tagged fields of the
+// struct out points to. Numbers sent as strings, dates and checkboxes sent as
+// "true"/"false" are converted. Fields missing from the map are left as is.
+// Every mismatch is reported in a CustomFieldErrors.
+func DecodeCustomFields(fields map[string]interface{}, out interface{}) error {
+	target := reflect.ValueOf(out)
+	if target.Kind() != reflect.Ptr || target.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("custom fields must be decoded into a pointer to a struct, got %T", out)
+	}
+	target = target.Elem()
+	errs := CustomFieldErrors{}
+	for i := 0; i < target.NumField(); i++ {
+		name, _, ok := customFieldTag(target.Type().Field(i))
+		if !ok {
+			continue
+		}
+		value, ok := fields[name]
+		if !ok {
+			continue
+		}
+		field := target.Field(i)
+		if !decodeCustomField(value, field) {
+			errs = append(errs, CustomFieldError{name, value, field.Type()})
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// EncodeCustomFields builds the custom_fields map from the tagged fields of a
+// struct or pointer to struct. Times are sent as dates, and zero times as
+// null so that they clear the field like other zero values.
+func EncodeCustomFields(in interface{}) (map[string]interface{}, error) {
+	source := reflect.ValueOf(in)
+	if source.Kind() == reflect.Ptr {
+		source = source.Elem()
+	}
+	if source.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("custom fields must be encoded from a struct, got %T", in)
+	}
+	fields := map[string]interface{}{}
+	for i := 0; i < source.NumField(); i++ {
+		name, omitEmpty, ok := customFieldTag(source.Type().Field(i))
+		if !ok {
+			continue
+		}
+		field := source.Field(i)
+		if omitEmpty && isZeroValue(field) {
+			continue
+		}
+		if field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				fields[name] = nil
+				continue
+			}
+			field = field.Elem()
+		}
+		if t, ok := field.Interface().(time.Time); ok {
+			if t.IsZero() {
+				fields[name] = nil
+			} else {
+				fields[name] = t.Format(customFieldDateFormat)
+			}
+			continue
+		}
+		fields[name] = field.Interface()
+	}
+	return fields, nil
+}
+
+// DecodeCustomFields decodes the ticket's custom fields, see DecodeCustomFields.
+func (t Ticket) DecodeCustomFields(out interface{}) error {
+	return DecodeCustomFields(t.CustomFields, out)
+}
+
+// DecodeCustomFields decodes the requester's custom fields, see DecodeCustomFields.
+func (r Requester) DecodeCustomFields(out interface{}) error {
+	return DecodeCustomFields(r.CustomFields, out)
+}
+
+// DecodeCustomFields decodes the department's custom fields, see DecodeCustomFields.
+func (d Department) DecodeCustomFields(out interface{}) error {
+	return DecodeCustomFields(d.CustomFields, out)
+}
+
+func customFieldTag(field reflect.StructField) (name string, omitEmpty bool, ok bool) {
+	tag, ok := field.Tag.Lookup(CustomFieldTag)
+	if !ok || tag == "-" || field.PkgPath != "" {
+		return "", false, false
+	}
+	parts := strings.Split(tag, ",")
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	if parts[0] == "" {
+		return field.Name, omitEmpty, true
+	}
+	return parts[0], omitEmpty, true
+}
+
+func isZeroValue(v reflect.Value) bool {
+	return reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
+}
+
+// decodeCustomField stores value into field, reporting whether it could.
+func decodeCustomField(value interface{}, field reflect.Value) bool {
+	if field.Kind() == reflect.Ptr {
+		if value == nil {
+			field.Set(reflect.Zero(field.Type()))
+			return true
+		}
+		elem := reflect.New(field.Type().Elem())
+		if !decodeCustomField(value, elem.Elem()) {
+			return false
+		}
+		field.Set(elem)
+		return true
+	}
+	if value == nil {
+		field.Set(reflect.Zero(field.Type()))
+		return true
+	}
+	if field.Type() == reflect.TypeOf(time.Time{}) {
+		text, ok := value.(string)
+		if !ok {
+			return false
+		}
+		for _, layout := range []string{customFieldDateFormat, time.RFC3339} {
+			if parsed, err := time.Parse(layout, text); err == nil {
+				field.Set(reflect.ValueOf(parsed))
+				return true
+			}
+		}
+		return false
+	}
+	switch field.Kind() {
+	case reflect.String:
+		switch v := value.(type) {
+		case string:
+			field.SetString(v)
+		case float64:
+			field.SetString(strconv.FormatFloat(v, 'f', -1, 64))
+		case bool:
+			field.SetString(strconv.FormatBool(v))
+		default:
+			return false
+		}
+	case reflect.Bool:
+		switch v := value.(type) {
+		case bool:
+			field.SetBool(v)
+		case string:
+			parsed, err := strconv.ParseBool(v)
+			if err != nil {
+				return false
+			}
+			field.SetBool(parsed)
+		case float64:
+			if v != 0 && v != 1 {
+				return false
+			}
+			field.SetBool(v == 1)
+		default:
+			return false
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, ok := customFieldNumber(value)
+		if !ok || number != math.Trunc(number) || field.OverflowInt(int64(number)) {
+			return false
+		}
+		field.SetInt(int64(number))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number, ok := customFieldNumber(value)
+		if !ok || number < 0 || number != math.Trunc(number) || field.OverflowUint(uint64(number)) {
+			return false
+		}
+		field.SetUint(uint64(number))
+	case reflect.Float32, reflect.Float64:
+		number, ok := customFieldNumber(value)
+		if !ok {
+			return false
+		}
+		field.SetFloat(number)
+	case reflect.Interface:
+		field.Set(reflect.ValueOf(value))
+	default:
+		return false
+	}
+	return true
+}
+
+func customFieldNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case string:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return parsed, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,115 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type onboarding struct {
+	Laptop    bool       `freshservice:"cf_needs_laptop"`
+	StartDate time.Time  `freshservice:"cf_start_date"`
+	EndDate   *time.Time `freshservice:"cf_end_date,omitempty"`
+	Review    *time.Time `freshservice:"cf_review"`
+	Cost      float64    `freshservice:"cf_cost"`
+	Seats     int        `freshservice:"cf_seats,omitempty"`
+	Team      string     `freshservice:"cf_team"`
+	Notes     string     `freshservice:",omitempty"`
+	Ignored   string
+	Skipped   string `freshservice:"-"`
+}
+
+func TestCustomFieldsRoundTrip(t *testing.T) {
+	start := time.Date(2020, 5, 4, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		in   onboarding
+		want map[string]interface{}
+	}{
+		{
+			"values",
+			onboarding{Laptop: true, StartDate: start, Review: &start, Cost: 1250.5, Seats: 3, Team: "Network", Notes: "n"},
+			map[string]interface{}{
+				"cf_needs_laptop": true, "cf_start_date": "2020-05-04", "cf_review": "2020-05-04",
+				"cf_cost": 1250.5, "cf_seats": 3.0, "cf_team": "Network", "Notes": "n",
+			},
+		},
+		{
+			"zero values clear the fields",
+			onboarding{},
+			map[string]interface{}{
+				"cf_needs_laptop": false, "cf_start_date": nil, "cf_review": nil, "cf_cost": 0.0, "cf_team": "",
+			},
+		},
+	}
+	for _, test := range tests {
+		fields, err := EncodeCustomFields(&test.in)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		// Go through JSON, as the fields do on their way to the API and back.
+		jsonb, err := json.Marshal(fields)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded := map[string]interface{}{}
+		if err := json.Unmarshal(jsonb, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(decoded, test.want) {
+			t.Errorf("%s: encoded %v, want %v", test.name, decoded, test.want)
+		}
+
+		out := onboarding{}
+		if err := DecodeCustomFields(decoded, &out); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if !reflect.DeepEqual(out, test.in) {
+			t.Errorf("%s: round trip %+v, want %+v", test.name, out, test.in)
+		}
+	}
+}
+
+func TestDecodeCustomFieldsConversions(t *testing.T) {
+	out := onboarding{}
+	err := DecodeCustomFields(map[string]interface{}{
+		"cf_needs_laptop": "true",
+		"cf_start_date":   "2020-05-04T10:00:00Z",
+		"cf_cost":         "99.5",
+		"cf_seats":        "4",
+		"cf_team":         12.0,
+		"Ignored":         "x",
+	}, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := onboarding{Laptop: true, StartDate: time.Date(2020, 5, 4, 10, 0, 0, 0, time.UTC), Cost: 99.5, Seats: 4, Team: "12"}
+	if !reflect.DeepEqual(out, want) {
+		t.Errorf("decoded %+v, want %+v", out, want)
+	}
+}
+
+func TestDecodeCustomFieldsErrors(t *testing.T) {
+	out := onboarding{}
+	err := DecodeCustomFields(map[string]interface{}{
+		"cf_needs_laptop": 2.0,
+		"cf_start_date":   "soon",
+		"cf_seats":        1.5,
+		"cf_team":         []interface{}{"a"},
+	}, &out)
+	errs, ok := err.(CustomFieldErrors)
+	if !ok || len(errs) != 4 {
+		t.Fatalf("error = %v", err)
+	}
+	if !strings.Contains(errs.Error(), "custom field cf_seats: cannot decode 1.5 into int") {
+		t.Errorf("error = %v", errs)
+	}
+	if err := DecodeCustomFields(nil, out); err == nil {
+		t.Error("decoding into a struct value succeeded")
+	}
+	if _, err := EncodeCustomFields(3); err == nil {
+		t.Error("encoding a number succeeded")
+	}
+}
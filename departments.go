@@ -4,15 +4,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/nextlinktechnology/go-freshservice/querybuilder"
 	"github.com/nextlinktechnology/mgm/v3"
 )
 
 type DepartmentManager interface {
 	All() (DepartmentSlice, error)
 	Create(CreateDepartment) (Department, error)
-	Update(int64, CreateDepartment) (Department, error)
+	View(int64) (Department, error)
+	Search(querybuilder.Query) (DepartmentSlice, error)
+	FindByName(string) (Department, error)
+	Update(int64, UpdateDepartment) (Department, error)
+	Delete(int64) error
 }
 
 type departmentManager struct {
@@ -30,8 +36,8 @@ type Department struct {
 	ID               int64                  `bson:"id" json:"id"`
 	Name             string                 `bson:"name" json:"name,omitempty"`
 	Description      string                 `bson:"description" json:"description,omitempty"`
-	HeadUserID       string                 `bson:"head_user_id" json:"head_user_id,omitempty"`
-	PrimeUserID      string                 `bson:"prime_user_id" json:"prime_user_id,omitempty"`
+	HeadUserID       int64                  `bson:"head_user_id" json:"head_user_id,omitempty"`
+	PrimeUserID      int64                  `bson:"prime_user_id" json:"prime_user_id,omitempty"`
 	Domains          []string               `bson:"domains" json:"domains,omitempty"`
 	CustomFields     map[string]interface{} `bson:"custom_fields" json:"custom_fields,omitempty"`
 	CreatedAt        *time.Time             `bson:"created_at" json:"created_at,omitempty"`
@@ -42,14 +48,26 @@ type CreateDepartment struct {
 	ID           int64                  `json:"id,omitempty"`
 	Name         string                 `json:"name,omitempty"`
 	Description  string                 `json:"description,omitempty"`
-	HeadUserID   string                 `json:"head_user_id,omitempty"`
-	PrimeUserID  string                 `json:"prime_user_id,omitempty"`
+	HeadUserID   int64                  `json:"head_user_id,omitempty"`
+	PrimeUserID  int64                  `json:"prime_user_id,omitempty"`
 	Domains      []string               `json:"domains,omitempty"`
 	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
 	CreatedAt    *time.Time             `json:"created_at,omitempty"`
 	UpdatedAt    *time.Time             `json:"updated_at,omitempty"`
 }
 
+// UpdateDepartment holds the fields to change on a department. Only non-nil
+// fields are sent, so zero and empty values can be set explicitly, and the
+// head and prime users cleared with Null.
+type UpdateDepartment struct {
+	Name         *string                `json:"name,omitempty"`
+	Description  *string                `json:"description,omitempty"`
+	HeadUserID   *NullInt64             `json:"head_user_id,omitempty"`
+	PrimeUserID  *NullInt64             `json:"prime_user_id,omitempty"`
+	Domains      *[]string              `json:"domains,omitempty"`
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+}
+
 type RespDepartment struct {
 	Departments []Department `json:"departments,omitempty"`
 }
@@ -74,10 +92,56 @@ func (c DepartmentSlice) Print() {
 	}
 }
 
+// DepartmentNameQuery builds the filter query matching departments by name.
+func DepartmentNameQuery(name string) querybuilder.Query {
+	query := querybuilder.BuildQuery()
+	query.Is("query", fmt.Sprintf("\"name:'%s'\"", strings.Replace(name, "'", "\\'", -1)))
+	return query
+}
+
 func (manager departmentManager) All() (DepartmentSlice, error) {
+	return manager.collect(endpoints.departments.all)
+}
+
+func (manager departmentManager) Search(query querybuilder.Query) (DepartmentSlice, error) {
+	return manager.collect(endpoints.departments.search(query.URLSafe()))
+}
+
+// FindByName returns the department whose name matches, ignoring case, or
+// ErrNotFound. A failed search, e.g. when rate limited, returns its error and
+// never ErrNotFound, so that callers do not create a duplicate department.
+func (manager departmentManager) FindByName(name string) (Department, error) {
+	departments, err := manager.Search(DepartmentNameQuery(name))
+	if err != nil {
+		return Department{}, err
+	}
+	for _, department := range departments {
+		if strings.EqualFold(department.Name, name) {
+			return department, nil
+		}
+	}
+	return Department{}, ErrNotFound
+}
+
+func (manager departmentManager) View(id int64) (Department, error) {
+	output := struct {
+		Department Department `json:"department,omitempty"`
+	}{}
+	_, err := manager.client.get(endpoints.departments.view(id), &output)
+	if err != nil {
+		return Department{}, err
+	}
+	return output.Department, nil
+}
+
+func (manager departmentManager) Delete(id int64) error {
+	return manager.client.delete(endpoints.departments.delete(id), http.StatusNoContent)
+}
+
+func (manager departmentManager) collect(path string) (DepartmentSlice, error) {
 	resp := RespDepartment{}
 	output := DepartmentSlice{}
-	headers, err := manager.client.get(endpoints.departments.all, &resp)
+	headers, err := manager.client.get(path, &resp)
 	if err != nil {
 		return DepartmentSlice{}, err
 	}
@@ -111,8 +175,12 @@ func (manager departmentManager) Create(department CreateDepartment) (Department
 	return output.Departments[0], nil
 }
 
-func (manager departmentManager) Update(id int64, department CreateDepartment) (Department, error) {
-	output := RespDepartment{}
+// Update sends the non-nil fields of the update. A nil entry in CustomFields
+// clears that custom field.
+func (manager departmentManager) Update(id int64, department UpdateDepartment) (Department, error) {
+	output := struct {
+		Department Department `json:"department,omitempty"`
+	}{}
 	jsonb, err := json.Marshal(department)
 	if err != nil {
 		return Department{}, err
@@ -121,5 +189,5 @@ func (manager departmentManager) Update(id int64, department CreateDepartment) (
 	if err != nil {
 		return Department{}, err
 	}
-	return output.Departments[0], nil
+	return output.Department, nil
 }
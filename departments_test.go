@@ -0,0 +1,37 @@
+package freshdesk
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestDepartmentFindByName(t *testing.T) {
+	tests := []struct {
+		name     string
+		response http.HandlerFunc
+		wantID   int64
+		wantErr  string
+	}{
+		{"found", respond(http.StatusOK, `{"departments": [{"id": 3, "name": "Finance EU"}, {"id": 4, "name": "finance"}]}`), 4, ""},
+		{"not found", respond(http.StatusOK, `{"departments": []}`), 0, ErrNotFound.Error()},
+		{"rate limited", respond(http.StatusTooManyRequests, `{"message": "slow down"}`), 0, "received status code 429 (2xx expected)"},
+		{"server error", respond(http.StatusInternalServerError, ``), 0, "received status code 500 (2xx expected)"},
+	}
+	for _, test := range tests {
+		client := testClient(test.response)
+		department, err := client.Departments.FindByName("Finance")
+		gotErr := ""
+		if err != nil {
+			gotErr = err.Error()
+		}
+		if gotErr != test.wantErr || department.ID != test.wantID {
+			t.Errorf("%s: FindByName() = %d, %q, want %d, %q", test.name, department.ID, gotErr, test.wantID, test.wantErr)
+		}
+	}
+}
+
+func TestDepartmentNameQuery(t *testing.T) {
+	if got, want := DepartmentNameQuery("O'Brien & Co").URLSafe(), `query=%22name%3A%27O%5C%27Brien%20%26%20Co%27%22`; got != want {
+		t.Errorf("DepartmentNameQuery() = %s, want %s", got, want)
+	}
+}
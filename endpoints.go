@@ -5,7 +5,10 @@ import "fmt"
 type departmentEndpoints struct {
 	all    string
 	create string
+	view   func(int64) string
+	search func(string) string
 	update func(int64) string
+	delete func(int64) string
 }
 
 type requesterEndpoints struct {
@@ -15,14 +18,137 @@ type requesterEndpoints struct {
 	update func(int64) string
 }
 
+type agentEndpoints struct {
+	all        string
+	create     string
+	search     func(string) string
+	view       func(int64) string
+	update     func(int64) string
+	deactivate func(int64) string
+	reactivate func(int64) string
+	forget     func(int64) string
+}
+
+type groupEndpoints struct {
+	all    string
+	create string
+	view   func(int64) string
+	update func(int64) string
+	delete func(int64) string
+}
+
+type requesterGroupEndpoints struct {
+	all     string
+	create  string
+	view    func(int64) string
+	update  func(int64) string
+	delete  func(int64) string
+	members func(int64) string
+	member  func(int64, int64) string
+}
+
+type locationEndpoints struct {
+	all    string
+	create string
+	view   func(int64) string
+	update func(int64) string
+	delete func(int64) string
+}
+
+type assetEndpoints struct {
+	create        string
+	search        func(string) string
+	view          func(int64) string
+	update        func(int64) string
+	delete        func(int64) string
+	restore       func(int64) string
+	deleteForever func(int64) string
+}
+
+type assetTypeEndpoints struct {
+	all    string
+	view   func(int64) string
+	fields func(int64) string
+}
+
+type relationshipEndpoints struct {
+	all        string
+	types      string
+	bulkCreate string
+	view       func(int64) string
+	asset      func(int64) string
+	delete     func(string) string
+}
+
+type changeEndpoints struct {
+	all         string
+	create      string
+	filter      func(string) string
+	view        func(int64) string
+	update      func(int64) string
+	delete      func(int64) string
+	notes       func(int64) string
+	tasks       func(int64) string
+	task        func(int64, int64) string
+	timeEntries func(int64) string
+	timeEntry   func(int64, int64) string
+}
+
+type problemEndpoints struct {
+	all    string
+	create string
+	view   func(int64) string
+	update func(int64) string
+	delete func(int64) string
+	notes  func(int64) string
+	tasks  func(int64) string
+	task   func(int64, int64) string
+}
+
+type releaseEndpoints struct {
+	all         string
+	create      string
+	view        func(int64) string
+	update      func(int64) string
+	delete      func(int64) string
+	notes       func(int64) string
+	tasks       func(int64) string
+	task        func(int64, int64) string
+	timeEntries func(int64) string
+}
+
+type projectEndpoints struct {
+	all    string
+	create string
+	view   func(int64) string
+	update func(int64) string
+	delete func(int64) string
+	tasks  func(int64) string
+	task   func(int64, int64) string
+}
+
 type ticketEndpoints struct {
-	all             string
-	create          string
-	view            func(int64) string
-	search          func(string) string
-	reply           func(int64) string
-	conversations   func(int64) string
-	updatedSinceAll func(string) string
+	all           string
+	create        string
+	merge         string
+	view          func(int64) string
+	include       func(int64, string) string
+	update        func(int64) string
+	search        func(string) string
+	reply         func(int64) string
+	conversations func(int64) string
+	notes         func(int64) string
+	activities    func(int64) string
+	tasks         func(int64) string
+	task          func(int64, int64) string
+	timeEntries   func(int64) string
+	timeEntry     func(int64, int64) string
+	createChild   func(int64) string
+	forward       func(int64) string
+}
+
+type ticketFieldEndpoints struct {
+	all string
 }
 
 type servicerequestEndpoints struct {
@@ -31,15 +157,30 @@ type servicerequestEndpoints struct {
 }
 
 var endpoints = struct {
-	departments    departmentEndpoints
-	requesters     requesterEndpoints
-	tickets        ticketEndpoints
-	servicerequest servicerequestEndpoints
+	departments     departmentEndpoints
+	requesters      requesterEndpoints
+	agents          agentEndpoints
+	groups          groupEndpoints
+	requesterGroups requesterGroupEndpoints
+	locations       locationEndpoints
+	assets          assetEndpoints
+	assetTypes      assetTypeEndpoints
+	relationships   relationshipEndpoints
+	changes         changeEndpoints
+	problems        problemEndpoints
+	releases        releaseEndpoints
+	projects        projectEndpoints
+	tickets         ticketEndpoints
+	ticketFields    ticketFieldEndpoints
+	servicerequest  servicerequestEndpoints
 }{
 	departments: departmentEndpoints{
 		all:    "/api/v2/departments",
 		create: "/api/v2/departments",
+		view:   func(id int64) string { return fmt.Sprintf("/api/v2/departments/%d", id) },
+		search: func(query string) string { return fmt.Sprintf("/api/v2/departments?%s", query) },
 		update: func(id int64) string { return fmt.Sprintf("/api/v2/departments/%d", id) },
+		delete: func(id int64) string { return fmt.Sprintf("/api/v2/departments/%d", id) },
 	},
 	requesters: requesterEndpoints{
 		all:    "/api/v2/requesters",
@@ -47,17 +188,134 @@ var endpoints = struct {
 		update: func(id int64) string { return fmt.Sprintf("/api/v2/requesters/%d", id) },
 		search: func(query string) string { return fmt.Sprintf("/api/v2/requesters?%s", query) },
 	},
+	agents: agentEndpoints{
+		all:        "/api/v2/agents",
+		create:     "/api/v2/agents",
+		search:     func(query string) string { return fmt.Sprintf("/api/v2/agents?%s", query) },
+		view:       func(id int64) string { return fmt.Sprintf("/api/v2/agents/%d", id) },
+		update:     func(id int64) string { return fmt.Sprintf("/api/v2/agents/%d", id) },
+		deactivate: func(id int64) string { return fmt.Sprintf("/api/v2/agents/%d", id) },
+		reactivate: func(id int64) string { return fmt.Sprintf("/api/v2/agents/%d/reactivate", id) },
+		forget:     func(id int64) string { return fmt.Sprintf("/api/v2/agents/%d/forget", id) },
+	},
+	groups: groupEndpoints{
+		all:    "/api/v2/groups",
+		create: "/api/v2/groups",
+		view:   func(id int64) string { return fmt.Sprintf("/api/v2/groups/%d", id) },
+		update: func(id int64) string { return fmt.Sprintf("/api/v2/groups/%d", id) },
+		delete: func(id int64) string { return fmt.Sprintf("/api/v2/groups/%d", id) },
+	},
+	requesterGroups: requesterGroupEndpoints{
+		all:     "/api/v2/requester_groups",
+		create:  "/api/v2/requester_groups",
+		view:    func(id int64) string { return fmt.Sprintf("/api/v2/requester_groups/%d", id) },
+		update:  func(id int64) string { return fmt.Sprintf("/api/v2/requester_groups/%d", id) },
+		delete:  func(id int64) string { return fmt.Sprintf("/api/v2/requester_groups/%d", id) },
+		members: func(id int64) string { return fmt.Sprintf("/api/v2/requester_groups/%d/members", id) },
+		member: func(id, requesterID int64) string {
+			return fmt.Sprintf("/api/v2/requester_groups/%d/members/%d", id, requesterID)
+		},
+	},
+	locations: locationEndpoints{
+		all:    "/api/v2/locations",
+		create: "/api/v2/locations",
+		view:   func(id int64) string { return fmt.Sprintf("/api/v2/locations/%d", id) },
+		update: func(id int64) string { return fmt.Sprintf("/api/v2/locations/%d", id) },
+		delete: func(id int64) string { return fmt.Sprintf("/api/v2/locations/%d", id) },
+	},
+	assets: assetEndpoints{
+		create:        "/api/v2/assets",
+		search:        func(query string) string { return fmt.Sprintf("/api/v2/assets?%s", query) },
+		view:          func(id int64) string { return fmt.Sprintf("/api/v2/assets/%d?include=type_fields", id) },
+		update:        func(id int64) string { return fmt.Sprintf("/api/v2/assets/%d", id) },
+		delete:        func(id int64) string { return fmt.Sprintf("/api/v2/assets/%d", id) },
+		restore:       func(id int64) string { return fmt.Sprintf("/api/v2/assets/%d/restore", id) },
+		deleteForever: func(id int64) string { return fmt.Sprintf("/api/v2/assets/%d/delete_forever", id) },
+	},
+	assetTypes: assetTypeEndpoints{
+		all:    "/api/v2/asset_types",
+		view:   func(id int64) string { return fmt.Sprintf("/api/v2/asset_types/%d", id) },
+		fields: func(id int64) string { return fmt.Sprintf("/api/v2/asset_types/%d/fields", id) },
+	},
+	relationships: relationshipEndpoints{
+		all:        "/api/v2/relationships",
+		types:      "/api/v2/relationship_types",
+		bulkCreate: "/api/v2/relationships/bulk-create",
+		view:       func(id int64) string { return fmt.Sprintf("/api/v2/relationships/%d", id) },
+		asset:      func(id int64) string { return fmt.Sprintf("/api/v2/assets/%d/relationships", id) },
+		delete:     func(ids string) string { return fmt.Sprintf("/api/v2/relationships?ids=%s", ids) },
+	},
+	changes: changeEndpoints{
+		all:         "/api/v2/changes",
+		create:      "/api/v2/changes",
+		filter:      func(query string) string { return fmt.Sprintf("/api/v2/changes/filter?%s", query) },
+		view:        func(id int64) string { return fmt.Sprintf("/api/v2/changes/%d", id) },
+		update:      func(id int64) string { return fmt.Sprintf("/api/v2/changes/%d", id) },
+		delete:      func(id int64) string { return fmt.Sprintf("/api/v2/changes/%d", id) },
+		notes:       func(id int64) string { return fmt.Sprintf("/api/v2/changes/%d/notes", id) },
+		tasks:       func(id int64) string { return fmt.Sprintf("/api/v2/changes/%d/tasks", id) },
+		task:        func(id, taskID int64) string { return fmt.Sprintf("/api/v2/changes/%d/tasks/%d", id, taskID) },
+		timeEntries: func(id int64) string { return fmt.Sprintf("/api/v2/changes/%d/time_entries", id) },
+		timeEntry: func(id, entryID int64) string {
+			return fmt.Sprintf("/api/v2/changes/%d/time_entries/%d", id, entryID)
+		},
+	},
+	problems: problemEndpoints{
+		all:    "/api/v2/problems",
+		create: "/api/v2/problems",
+		view:   func(id int64) string { return fmt.Sprintf("/api/v2/problems/%d", id) },
+		update: func(id int64) string { return fmt.Sprintf("/api/v2/problems/%d", id) },
+		delete: func(id int64) string { return fmt.Sprintf("/api/v2/problems/%d", id) },
+		notes:  func(id int64) string { return fmt.Sprintf("/api/v2/problems/%d/notes", id) },
+		tasks:  func(id int64) string { return fmt.Sprintf("/api/v2/problems/%d/tasks", id) },
+		task:   func(id, taskID int64) string { return fmt.Sprintf("/api/v2/problems/%d/tasks/%d", id, taskID) },
+	},
+	releases: releaseEndpoints{
+		all:         "/api/v2/releases",
+		create:      "/api/v2/releases",
+		view:        func(id int64) string { return fmt.Sprintf("/api/v2/releases/%d", id) },
+		update:      func(id int64) string { return fmt.Sprintf("/api/v2/releases/%d", id) },
+		delete:      func(id int64) string { return fmt.Sprintf("/api/v2/releases/%d", id) },
+		notes:       func(id int64) string { return fmt.Sprintf("/api/v2/releases/%d/notes", id) },
+		tasks:       func(id int64) string { return fmt.Sprintf("/api/v2/releases/%d/tasks", id) },
+		task:        func(id, taskID int64) string { return fmt.Sprintf("/api/v2/releases/%d/tasks/%d", id, taskID) },
+		timeEntries: func(id int64) string { return fmt.Sprintf("/api/v2/releases/%d/time_entries", id) },
+	},
+	projects: projectEndpoints{
+		all:    "/api/v2/projects",
+		create: "/api/v2/projects",
+		view:   func(id int64) string { return fmt.Sprintf("/api/v2/projects/%d", id) },
+		update: func(id int64) string { return fmt.Sprintf("/api/v2/projects/%d", id) },
+		delete: func(id int64) string { return fmt.Sprintf("/api/v2/projects/%d", id) },
+		tasks:  func(id int64) string { return fmt.Sprintf("/api/v2/projects/%d/tasks", id) },
+		task:   func(id, taskID int64) string { return fmt.Sprintf("/api/v2/projects/%d/tasks/%d", id, taskID) },
+	},
 	tickets: ticketEndpoints{
 		all:           "/api/v2/tickets",
 		create:        "/api/v2/tickets",
+		merge:         "/api/v2/tickets/merge",
 		view:          func(id int64) string { return fmt.Sprintf("/api/v2/tickets/%d", id) },
+		update:        func(id int64) string { return fmt.Sprintf("/api/v2/tickets/%d", id) },
 		search:        func(query string) string { return fmt.Sprintf("/api/v2/tickets?%s", query) },
 		reply:         func(id int64) string { return fmt.Sprintf("/api/v2/tickets/%d/reply", id) },
 		conversations: func(id int64) string { return fmt.Sprintf("/api/v2/tickets/%d/conversations", id) },
-		updatedSinceAll: func(timeString string) string {
-			return fmt.Sprintf("/api/v2/tickets?updated_since=%s", timeString)
+		notes:         func(id int64) string { return fmt.Sprintf("/api/v2/tickets/%d/notes", id) },
+		activities:    func(id int64) string { return fmt.Sprintf("/api/v2/tickets/%d/activities", id) },
+		tasks:         func(id int64) string { return fmt.Sprintf("/api/v2/tickets/%d/tasks", id) },
+		task:          func(id, taskID int64) string { return fmt.Sprintf("/api/v2/tickets/%d/tasks/%d", id, taskID) },
+		timeEntries:   func(id int64) string { return fmt.Sprintf("/api/v2/tickets/%d/time_entries", id) },
+		createChild:   func(id int64) string { return fmt.Sprintf("/api/v2/tickets/%d/create_child_ticket", id) },
+		forward:       func(id int64) string { return fmt.Sprintf("/api/v2/tickets/%d/forward", id) },
+		timeEntry: func(id, entryID int64) string {
+			return fmt.Sprintf("/api/v2/tickets/%d/time_entries/%d", id, entryID)
+		},
+		include: func(id int64, include string) string {
+			return fmt.Sprintf("/api/v2/tickets/%d?include=%s", id, include)
 		},
 	},
+	ticketFields: ticketFieldEndpoints{
+		all: "/api/v2/ticket_form_fields",
+	},
 	servicerequest: servicerequestEndpoints{
 		create: func(id int64) string { return fmt.Sprintf("/api/v2/service_catalog/items/%d/place_request", id) },
 		view:   func(id int64) string { return fmt.Sprintf("/api/v2/tickets/%d/requested_items", id) },
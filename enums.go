@@ -0,0 +1,165 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The built-in names of the enumerations. Accounts with custom values should
+// use TicketMetadata to render them.
+var (
+	sourceNames = Choices{
+		SourceEmail.Value():          "Email",
+		SourcePortal.Value():         "Portal",
+		SourcePhone.Value():          "Phone",
+		SourceChat.Value():           "Chat",
+		SourceFeedbackWidget.Value(): "Feedback Widget",
+		SourceYammer.Value():         "Yammer",
+		SourceAWSCloudwatch.Value():  "AWS Cloudwatch",
+		SourcePagerduty.Value():      "Pagerduty",
+		SourceWalkup.Value():         "Walkup",
+		SourceSlack.Value():          "Slack",
+	}
+	statusNames = Choices{
+		StatusOpen.Value():     "Open",
+		StatusPending.Value():  "Pending",
+		StatusResolved.Value(): "Resolved",
+		StatusClosed.Value():   "Closed",
+	}
+	priorityNames = Choices{
+		PriorityLow.Value():    "Low",
+		PriorityMedium.Value(): "Medium",
+		PriorityHigh.Value():   "High",
+		PriorityUrgent.Value(): "Urgent",
+	}
+	srStageNames = Choices{
+		SRStageRequested.Value():          "Requested",
+		SRStageDelivered.Value():          "Delivered",
+		SRStageCancelled.Value():          "Cancelled",
+		SRStageFulfilled.Value():          "Fulfilled",
+		SRStagePartiallyFulfilled.Value(): "Partially Fulfilled",
+	}
+)
+
+// The enumerations marshal to text as their names, so they read well in logs
+// and text formats. Their JSON form stays numeric as the API expects it, but
+// both numbers and names are accepted when unmarshaling.
+
+func (s Source) String() string { return sourceNames.Name(s.Value()) }
+
+func (s Status) String() string { return statusNames.Name(s.Value()) }
+
+func (p Priority) String() string { return priorityNames.Name(p.Value()) }
+
+func (s SRStage) String() string { return srStageNames.Name(s.Value()) }
+
+// ParseSource parses a source name, ignoring case, or its numeric value.
+func ParseSource(text string) (Source, error) {
+	value, err := parseChoice("source", text, sourceNames)
+	return Source(value), err
+}
+
+// ParseStatus parses a status name, ignoring case, or its numeric value.
+func ParseStatus(text string) (Status, error) {
+	value, err := parseChoice("status", text, statusNames)
+	return Status(value), err
+}
+
+// ParsePriority parses a priority name, ignoring case, or its numeric value.
+func ParsePriority(text string) (Priority, error) {
+	value, err := parseChoice("priority", text, priorityNames)
+	return Priority(value), err
+}
+
+// ParseSRStage parses a service request stage name, ignoring case, or its numeric value.
+func ParseSRStage(text string) (SRStage, error) {
+	value, err := parseChoice("service request stage", text, srStageNames)
+	return SRStage(value), err
+}
+
+func (s Source) MarshalText() ([]byte, error) { return []byte(s.String()), nil }
+
+func (s Status) MarshalText() ([]byte, error) { return []byte(s.String()), nil }
+
+func (p Priority) MarshalText() ([]byte, error) { return []byte(p.String()), nil }
+
+func (s SRStage) MarshalText() ([]byte, error) { return []byte(s.String()), nil }
+
+func (s *Source) UnmarshalText(text []byte) (err error) {
+	*s, err = ParseSource(string(text))
+	return err
+}
+
+func (s *Status) UnmarshalText(text []byte) (err error) {
+	*s, err = ParseStatus(string(text))
+	return err
+}
+
+func (p *Priority) UnmarshalText(text []byte) (err error) {
+	*p, err = ParsePriority(string(text))
+	return err
+}
+
+func (s *SRStage) UnmarshalText(text []byte) (err error) {
+	*s, err = ParseSRStage(string(text))
+	return err
+}
+
+func (s Source) MarshalJSON() ([]byte, error) { return json.Marshal(s.Value()) }
+
+func (s Status) MarshalJSON() ([]byte, error) { return json.Marshal(s.Value()) }
+
+func (p Priority) MarshalJSON() ([]byte, error) { return json.Marshal(p.Value()) }
+
+func (s SRStage) MarshalJSON() ([]byte, error) { return json.Marshal(s.Value()) }
+
+func (s *Source) UnmarshalJSON(data []byte) error { return unmarshalChoiceJSON(data, s) }
+
+func (s *Status) UnmarshalJSON(data []byte) error { return unmarshalChoiceJSON(data, s) }
+
+func (p *Priority) UnmarshalJSON(data []byte) error { return unmarshalChoiceJSON(data, p) }
+
+func (s *SRStage) UnmarshalJSON(data []byte) error { return unmarshalChoiceJSON(data, s) }
+
+// ExportJSON encodes the ticket like json.Marshal, but with the status,
+// priority and source rendered by name, for exports and logs.
+func (t Ticket) ExportJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Ticket
+		Priority string `json:"priority"`
+		Source   string `json:"source"`
+		Status   string `json:"status"`
+	}{t, t.Priority.String(), t.Source.String(), t.Status.String()})
+}
+
+func parseChoice(kind, text string, names Choices) (int, error) {
+	text = strings.TrimSpace(text)
+	if value, err := strconv.Atoi(text); err == nil {
+		return value, nil
+	}
+	if value, ok := names.Value(text); ok {
+		return value, nil
+	}
+	return 0, fmt.Errorf("unknown %s %q", kind, text)
+}
+
+// unmarshalChoiceJSON accepts a JSON number, or a string holding a name or
+// number, and stores it through the target's UnmarshalText.
+func unmarshalChoiceJSON(data []byte, target interface {
+	UnmarshalText([]byte) error
+}) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		var number json.Number
+		if err := json.Unmarshal(data, &number); err != nil {
+			return err
+		}
+		text = number.String()
+	}
+	return target.UnmarshalText([]byte(text))
+}
@@ -0,0 +1,127 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEnumJSONRoundTrip(t *testing.T) {
+	in := struct {
+		Status   Status   `json:"status"`
+		Priority Priority `json:"priority"`
+		Source   Source   `json:"source"`
+		Stage    SRStage  `json:"stage"`
+	}{StatusPending, PriorityUrgent, SourceChat, SRStageFulfilled}
+	jsonb, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"status":3,"priority":4,"source":4,"stage":4}`
+	if string(jsonb) != want {
+		t.Errorf("json = %s, want %s", jsonb, want)
+	}
+	out := in
+	out.Status, out.Priority, out.Source, out.Stage = 0, 0, 0, 0
+	if err := json.Unmarshal(jsonb, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out != in {
+		t.Errorf("round trip = %+v, want %+v", out, in)
+	}
+}
+
+func TestEnumUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json    string
+		want    Status
+		wantErr bool
+	}{
+		{`2`, StatusOpen, false},
+		{`"5"`, StatusClosed, false},
+		{`"pending"`, StatusPending, false},
+		{`" Resolved "`, StatusResolved, false},
+		{`6`, Status(6), false},
+		{`null`, 0, false},
+		{`"Sleeping"`, 0, true},
+		{`true`, 0, true},
+	}
+	for _, test := range tests {
+		var got Status
+		err := json.Unmarshal([]byte(test.json), &got)
+		if (err != nil) != test.wantErr || got != test.want {
+			t.Errorf("Unmarshal(%s) = %d, %v", test.json, got, err)
+		}
+	}
+}
+
+func TestEnumText(t *testing.T) {
+	tests := []struct {
+		value interface {
+			MarshalText() ([]byte, error)
+		}
+		want string
+	}{
+		{StatusResolved, "Resolved"},
+		{PriorityMedium, "Medium"},
+		{SourceFeedbackWidget, "Feedback Widget"},
+		{SRStagePartiallyFulfilled, "Partially Fulfilled"},
+		{Status(9), "9"},
+		{Priority(0), "0"},
+	}
+	for _, test := range tests {
+		text, err := test.value.MarshalText()
+		if err != nil || string(text) != test.want {
+			t.Errorf("MarshalText(%v) = %s, %v, want %s", test.value, text, err, test.want)
+		}
+	}
+
+	var source Source
+	if err := source.UnmarshalText([]byte("feedback widget")); err != nil || source != SourceFeedbackWidget {
+		t.Errorf("UnmarshalText() = %d, %v", source, err)
+	}
+	var priority Priority
+	if err := priority.UnmarshalText([]byte("Critical")); err == nil || err.Error() != `unknown priority "Critical"` {
+		t.Errorf("UnmarshalText(Critical) error = %v", err)
+	}
+	if _, err := ParseSRStage("Lost"); err == nil {
+		t.Error("ParseSRStage(Lost) succeeded")
+	}
+}
+
+func TestTicketExportJSON(t *testing.T) {
+	ticket := Ticket{ID: 7, Subject: "Printer", Status: StatusOpen, Priority: PriorityHigh, Source: Source(42)}
+	jsonb, err := ticket.ExportJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	exported := map[string]interface{}{}
+	if err := json.Unmarshal(jsonb, &exported); err != nil {
+		t.Fatal(err)
+	}
+	for field, want := range map[string]interface{}{"id": 7.0, "subject": "Printer", "status": "Open", "priority": "High", "source": "42"} {
+		if exported[field] != want {
+			t.Errorf("%s = %#v, want %#v", field, exported[field], want)
+		}
+	}
+
+	// The API form stays numeric.
+	jsonb, err = json.Marshal(ticket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := map[string]interface{}{}
+	json.Unmarshal(jsonb, &api)
+	if api["status"] != 2.0 || api["priority"] != 3.0 {
+		t.Errorf("json.Marshal() status = %v, priority = %v", api["status"], api["priority"])
+	}
+}
+
+func TestDepartmentUserIDs(t *testing.T) {
+	department := Department{}
+	if err := json.Unmarshal([]byte(`{"id": 3, "head_user_id": 1200001, "prime_user_id": null}`), &department); err != nil {
+		t.Fatal(err)
+	}
+	if department.HeadUserID != 1200001 || department.PrimeUserID != 0 {
+		t.Errorf("department = %+v", department)
+	}
+}
@@ -1,5 +1,10 @@
 package freshdesk
 
+import "errors"
+
+// ErrNotFound is returned by lookups that find no matching record.
+var ErrNotFound = errors.New("not found")
+
 type APIError struct {
 	error
 	APIError string
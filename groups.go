@@ -0,0 +1,287 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nextlinktechnology/mgm/v3"
+)
+
+type GroupManager interface {
+	All() (GroupSlice, error)
+	View(int64) (Group, error)
+	FindByName(string) (Group, error)
+	Create(CreateGroup) (Group, error)
+	Update(int64, CreateGroup) (Group, error)
+	Delete(int64) error
+	AddMembers(int64, ...int64) (Group, error)
+	RemoveMembers(int64, ...int64) (Group, error)
+	SetMembers(int64, []int64) (Group, error)
+}
+
+type groupManager struct {
+	client *ApiClient
+}
+
+func newGroupManager(client *ApiClient) groupManager {
+	return groupManager{
+		client,
+	}
+}
+
+// Group is an agent group, referenced by Ticket.GroupID.
+type Group struct {
+	mgm.DefaultModel `bson:",inline" json:"-"`
+	ID               int64      `bson:"id" json:"id"`
+	Name             string     `bson:"name" json:"name"`
+	Description      string     `bson:"description" json:"description"`
+	EscalateTo       int64      `bson:"escalate_to" json:"escalate_to"`
+	UnassignedFor    string     `bson:"unassigned_for" json:"unassigned_for"`
+	BusinessHoursID  int64      `bson:"business_hours_id" json:"business_hours_id"`
+	Members          []int64    `bson:"members" json:"members"`
+	Observers        []int64    `bson:"observers" json:"observers"`
+	Leaders          []int64    `bson:"leaders" json:"leaders"`
+	Restricted       bool       `bson:"restricted" json:"restricted"`
+	ApprovalRequired bool       `bson:"approval_required" json:"approval_required"`
+	AutoTicketAssign bool       `bson:"auto_ticket_assign" json:"auto_ticket_assign"`
+	CreatedAt        *time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt        *time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+type CreateGroup struct {
+	Name             string  `json:"name,omitempty"`
+	Description      string  `json:"description,omitempty"`
+	EscalateTo       int64   `json:"escalate_to,omitempty"`
+	UnassignedFor    string  `json:"unassigned_for,omitempty"`
+	BusinessHoursID  int64   `json:"business_hours_id,omitempty"`
+	Members          []int64 `json:"members,omitempty"`
+	Observers        []int64 `json:"observers,omitempty"`
+	Leaders          []int64 `json:"leaders,omitempty"`
+	Restricted       bool    `json:"restricted,omitempty"`
+	ApprovalRequired bool    `json:"approval_required,omitempty"`
+	AutoTicketAssign bool    `json:"auto_ticket_assign,omitempty"`
+}
+
+type RespGroups struct {
+	Groups []Group `json:"groups,omitempty"`
+}
+
+type RespGroup struct {
+	Group Group `json:"group,omitempty"`
+}
+
+type GroupSlice []Group
+
+func (s GroupSlice) Len() int { return len(s) }
+
+func (s GroupSlice) Less(i, j int) bool { return s[i].ID < s[j].ID }
+
+func (s GroupSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s GroupSlice) Print() {
+	for _, group := range s {
+		fmt.Println(group.Name)
+	}
+}
+
+// Find returns the group with the given ID, e.g. to resolve Ticket.GroupID.
+func (s GroupSlice) Find(id int64) (Group, bool) {
+	for _, group := range s {
+		if group.ID == id {
+			return group, true
+		}
+	}
+	return Group{}, false
+}
+
+func (manager groupManager) All() (GroupSlice, error) {
+	resp := RespGroups{}
+	output := GroupSlice{}
+	headers, err := manager.client.get(endpoints.groups.all, &resp)
+	if err != nil {
+		return GroupSlice{}, err
+	}
+	output = append(output, resp.Groups...)
+
+	for {
+		nextLink := manager.client.getNextLink(headers)
+		if nextLink == "" {
+			break
+		}
+		nextResp := RespGroups{}
+		headers, err = manager.client.get(nextLink, &nextResp)
+		if err != nil {
+			return GroupSlice{}, err
+		}
+		output = append(output, nextResp.Groups...)
+	}
+	return output, nil
+}
+
+func (manager groupManager) View(id int64) (Group, error) {
+	output := RespGroup{}
+	_, err := manager.client.get(endpoints.groups.view(id), &output)
+	if err != nil {
+		return Group{}, err
+	}
+	return output.Group, nil
+}
+
+// FindByName returns the group whose name matches, ignoring case, or ErrNotFound.
+// The groups endpoint has no filter, so every page is fetched.
+func (manager groupManager) FindByName(name string) (Group, error) {
+	groups, err := manager.All()
+	if err != nil {
+		return Group{}, err
+	}
+	for _, group := range groups {
+		if strings.EqualFold(group.Name, name) {
+			return group, nil
+		}
+	}
+	return Group{}, ErrNotFound
+}
+
+func (manager groupManager) Create(group CreateGroup) (Group, error) {
+	output := RespGroup{}
+	jsonb, err := json.Marshal(group)
+	if err != nil {
+		return Group{}, err
+	}
+	err = manager.client.postJSON(endpoints.groups.create, jsonb, &output, http.StatusCreated)
+	if err != nil {
+		return Group{}, err
+	}
+	return output.Group, nil
+}
+
+func (manager groupManager) Update(id int64, group CreateGroup) (Group, error) {
+	output := RespGroup{}
+	jsonb, err := json.Marshal(group)
+	if err != nil {
+		return Group{}, err
+	}
+	err = manager.client.put(endpoints.groups.update(id), jsonb, &output, http.StatusOK)
+	if err != nil {
+		return Group{}, err
+	}
+	return output.Group, nil
+}
+
+func (manager groupManager) Delete(id int64) error {
+	return manager.client.delete(endpoints.groups.delete(id), http.StatusNoContent)
+}
+
+// AddMembers adds the agents to the group, keeping its current members.
+//
+// The API only replaces the whole member list, so AddMembers reads the list
+// and writes it back: members added or removed by someone else in between are
+// overwritten. Nothing is written unless the group could be read, and it
+// returns an error if the group in the response is missing any of the agents.
+func (manager groupManager) AddMembers(id int64, agentIDs ...int64) (Group, error) {
+	group, err := manager.currentMembers(id)
+	if err != nil {
+		return Group{}, err
+	}
+	add, _ := diffMembers(group.Members, agentIDs)
+	members := append(append([]int64{}, group.Members...), add...)
+	group, err = manager.SetMembers(id, members)
+	if err != nil {
+		return Group{}, err
+	}
+	if missing, _ := diffMembers(group.Members, agentIDs); len(missing) > 0 {
+		return group, fmt.Errorf("adding members to group %d: agents %v missing from the updated group", id, missing)
+	}
+	return group, nil
+}
+
+// RemoveMembers removes the agents from the group, keeping its other members.
+// Like AddMembers it rewrites the whole member list, and returns an error if
+// any of the agents is still a member in the response.
+func (manager groupManager) RemoveMembers(id int64, agentIDs ...int64) (Group, error) {
+	group, err := manager.currentMembers(id)
+	if err != nil {
+		return Group{}, err
+	}
+	members := []int64{}
+	for _, member := range group.Members {
+		if containsID(agentIDs, member) {
+			continue
+		}
+		members = append(members, member)
+	}
+	group, err = manager.SetMembers(id, members)
+	if err != nil {
+		return Group{}, err
+	}
+	remaining := []int64{}
+	for _, member := range group.Members {
+		if containsID(agentIDs, member) {
+			remaining = append(remaining, member)
+		}
+	}
+	if len(remaining) > 0 {
+		return group, fmt.Errorf("removing members from group %d: agents %v still in the updated group", id, remaining)
+	}
+	return group, nil
+}
+
+// currentMembers reads the group before its member list is rewritten, making
+// sure the response really is the group so that an empty read never wipes it.
+func (manager groupManager) currentMembers(id int64) (Group, error) {
+	group, err := manager.View(id)
+	if err != nil {
+		return Group{}, err
+	}
+	if group.ID != id {
+		return Group{}, fmt.Errorf("reading group %d: response holds no group", id)
+	}
+	return group, nil
+}
+
+// SetMembers replaces the members of the group with the given agents.
+func (manager groupManager) SetMembers(id int64, agentIDs []int64) (Group, error) {
+	output := RespGroup{}
+	if agentIDs == nil {
+		agentIDs = []int64{}
+	}
+	jsonb, err := json.Marshal(struct {
+		Members []int64 `json:"members"`
+	}{agentIDs})
+	if err != nil {
+		return Group{}, err
+	}
+	err = manager.client.put(endpoints.groups.update(id), jsonb, &output, http.StatusOK)
+	if err != nil {
+		return Group{}, err
+	}
+	return output.Group, nil
+}
+
+// diffMembers returns the IDs in desired missing from current, and the IDs in
+// current missing from desired.
+func diffMembers(current, desired []int64) (add, remove []int64) {
+	for _, id := range desired {
+		if !containsID(current, id) && !containsID(add, id) {
+			add = append(add, id)
+		}
+	}
+	for _, id := range current {
+		if !containsID(desired, id) {
+			remove = append(remove, id)
+		}
+	}
+	return add, remove
+}
+
+func containsID(ids []int64, id int64) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// fakeGroupAPI serves a group's view and update endpoints, recording the
+// member lists written.
+type fakeGroupAPI struct {
+	view    http.HandlerFunc
+	written [][]int64
+}
+
+func (api *fakeGroupAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		api.view(w, r)
+		return
+	}
+	payload := struct {
+		Members []int64 `json:"members"`
+	}{}
+	json.NewDecoder(r.Body).Decode(&payload)
+	api.written = append(api.written, payload.Members)
+	members, _ := json.Marshal(payload.Members)
+	fmt.Fprintf(w, `{"group": {"id": 7, "members": %s}}`, members)
+}
+
+func TestGroupMembers(t *testing.T) {
+	group := respond(http.StatusOK, `{"group": {"id": 7, "members": [1, 2]}}`)
+	tests := []struct {
+		name        string
+		view        http.HandlerFunc
+		remove      bool
+		wantWritten [][]int64
+		wantErr     bool
+	}{
+		{"add", group, false, [][]int64{{1, 2, 3}}, false},
+		{"remove", group, true, [][]int64{{1}}, false},
+		{"add after failed read", respond(http.StatusTooManyRequests, ``), false, nil, true},
+		{"remove after failed read", respond(http.StatusServiceUnavailable, ``), true, nil, true},
+		{"add after empty read", respond(http.StatusOK, `{}`), false, nil, true},
+		{"remove after empty read", respond(http.StatusOK, `{"group": {}}`), true, nil, true},
+	}
+	for _, test := range tests {
+		api := &fakeGroupAPI{view: test.view}
+		client := testClient(api.ServeHTTP)
+		var err error
+		if test.remove {
+			_, err = client.Groups.RemoveMembers(7, 2)
+		} else {
+			_, err = client.Groups.AddMembers(7, 2, 3)
+		}
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: error = %v", test.name, err)
+		}
+		if !reflect.DeepEqual(api.written, test.wantWritten) {
+			t.Errorf("%s: written %v, want %v", test.name, api.written, test.wantWritten)
+		}
+	}
+}
+
+func TestGroupMembersUnchangedResponse(t *testing.T) {
+	client := testClient(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"group": {"id": 7, "members": [1, 2]}}`)
+	})
+	if _, err := client.Groups.AddMembers(7, 3); err == nil {
+		t.Error("AddMembers() accepted a response without the new member")
+	}
+	if _, err := client.Groups.RemoveMembers(7, 2); err == nil {
+		t.Error("RemoveMembers() accepted a response still holding the member")
+	}
+}
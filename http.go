@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"strings"
@@ -14,7 +15,8 @@ const httpClientTimeout = time.Second * 10
 
 func (c *ApiClient) postJSON(path string, requestBody []byte, out interface{}, expectedStatus int) error {
 	httpClient := &http.Client{
-		Timeout: httpClientTimeout,
+		Timeout:   httpClientTimeout,
+		Transport: c.transport,
 	}
 	if c.logger != nil {
 		c.logger.Println(string(requestBody))
@@ -52,6 +54,9 @@ func (c *ApiClient) postJSON(path string, requestBody []byte, out interface{}, e
 		}
 	}
 
+	if out == nil {
+		return nil
+	}
 	err = json.NewDecoder(res.Body).Decode(out)
 
 	return err
@@ -59,7 +64,8 @@ func (c *ApiClient) postJSON(path string, requestBody []byte, out interface{}, e
 
 func (c *ApiClient) put(path string, requestBody []byte, out interface{}, expectedStatus int) error {
 	httpClient := &http.Client{
-		Timeout: httpClientTimeout,
+		Timeout:   httpClientTimeout,
+		Transport: c.transport,
 	}
 	if c.logger != nil {
 		c.logger.Println(string(requestBody))
@@ -97,6 +103,9 @@ func (c *ApiClient) put(path string, requestBody []byte, out interface{}, expect
 		}
 	}
 
+	if out == nil {
+		return nil
+	}
 	err = json.NewDecoder(res.Body).Decode(out)
 
 	return err
@@ -104,7 +113,8 @@ func (c *ApiClient) put(path string, requestBody []byte, out interface{}, expect
 
 func (c *ApiClient) get(path string, out interface{}) (http.Header, error) {
 	httpClient := &http.Client{
-		Timeout: httpClientTimeout,
+		Timeout:   httpClientTimeout,
+		Transport: c.transport,
 	}
 	req, err := http.NewRequest("GET", fmt.Sprintf("https://%s.freshservice.com%s", c.domain, path), nil)
 	if err != nil {
@@ -122,21 +132,26 @@ func (c *ApiClient) get(path string, out interface{}) (http.Header, error) {
 	}
 	defer res.Body.Close()
 
-	c.logRes(res)
-
-	// body, err := ioutil.ReadAll(res.Body)
-	// var f interface{}
-	// err = json.Unmarshal(body, &f)
-	// if err != nil {
-	// 	log.Printf("Unable to Unmarshal request")
-	// }
-
-	// data := f.(map[string]interface{})
-	// log.Println(data)
-
-	json.NewDecoder(res.Body).Decode(out)
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		body, _ := ioutil.ReadAll(res.Body)
+		apiError := string(body)
+		var jsonBuffer bytes.Buffer
+		if json.Indent(&jsonBuffer, body, "", "\t") == nil {
+			apiError = jsonBuffer.String()
+		}
+		if c.logger != nil {
+			c.logger.Println("Status:", res.StatusCode, apiError)
+		}
+		return res.Header, APIError{
+			fmt.Errorf("received status code %d (2xx expected)", res.StatusCode),
+			apiError,
+		}
+	}
 
-	return res.Header, err
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil && err != io.EOF {
+		return res.Header, err
+	}
+	return res.Header, nil
 }
 
 func (c *ApiClient) getNextLink(headers http.Header) string {
@@ -147,9 +162,10 @@ func (c *ApiClient) getNextLink(headers http.Header) string {
 	return ""
 }
 
-func (c *ApiClient) delete(path string) error {
+func (c *ApiClient) delete(path string, expectedStatus int) error {
 	httpClient := &http.Client{
-		Timeout: httpClientTimeout,
+		Timeout:   httpClientTimeout,
+		Transport: c.transport,
 	}
 	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("https://%s.freshservice.com%s", c.domain, path), nil)
 	if err != nil {
@@ -166,8 +182,20 @@ func (c *ApiClient) delete(path string) error {
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode != 200 {
-		return fmt.Errorf("received status code %d (200 expected)", res.StatusCode)
+	if res.StatusCode != expectedStatus {
+		body, err := ioutil.ReadAll(res.Body)
+		var apiError string
+		if err == nil {
+			var jsonBuffer bytes.Buffer
+			err := json.Indent(&jsonBuffer, body, "", "\t")
+			if err == nil {
+				apiError = string(jsonBuffer.Bytes())
+			}
+		}
+		return APIError{
+			fmt.Errorf("received status code %d (%d expected)", res.StatusCode, expectedStatus),
+			apiError,
+		}
 	}
 
 	return nil
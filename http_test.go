@@ -0,0 +1,75 @@
+package freshdesk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// handlerTransport answers the client's requests with a handler, without
+// going through the network.
+type handlerTransport struct {
+	handler http.Handler
+}
+
+func (transport handlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorder := httptest.NewRecorder()
+	transport.handler.ServeHTTP(recorder, req)
+	return recorder.Result(), nil
+}
+
+func testClient(handler http.HandlerFunc) ApiClient {
+	return Init("acme", "key", &ClientOptions{Transport: handlerTransport{handler}})
+}
+
+// respond returns a handler answering every request with the status and body.
+func respond(status int, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}
+}
+
+func TestGetStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{"ok", http.StatusOK, `{"department": {"id": 1}}`, ""},
+		{"not found", http.StatusNotFound, `{"code": "access_denied"}`, "received status code 404 (2xx expected)"},
+		{"rate limited", http.StatusTooManyRequests, ``, "received status code 429 (2xx expected)"},
+		{"server error", http.StatusBadGateway, `<html>`, "received status code 502 (2xx expected)"},
+		{"undecodable", http.StatusOK, `{"department": `, "unexpected EOF"},
+	}
+	for _, test := range tests {
+		client := testClient(respond(test.status, test.body))
+		out := struct {
+			Department Department `json:"department"`
+		}{}
+		_, err := client.get("/api/v2/departments/1", &out)
+		if test.wantErr == "" {
+			if err != nil || out.Department.ID != 1 {
+				t.Errorf("%s: get() = %+v, %v", test.name, out, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+			t.Errorf("%s: get() error = %v, want %q", test.name, err, test.wantErr)
+		}
+	}
+}
+
+func TestGetAPIError(t *testing.T) {
+	client := testClient(respond(http.StatusNotFound, `{"code":"not_found"}`))
+	_, err := client.get("/api/v2/departments/1", &struct{}{})
+	apiErr, ok := err.(APIError)
+	if !ok {
+		t.Fatalf("get() error = %#v, want an APIError", err)
+	}
+	if want := "{\n\t\"code\": \"not_found\"\n}"; apiErr.APIError != want {
+		t.Errorf("APIError = %q, want %q", apiErr.APIError, want)
+	}
+}
@@ -0,0 +1,273 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nextlinktechnology/mgm/v3"
+)
+
+// LocationPathSeparator separates location names in a path such as "HQ/Floor 3/Lab".
+const LocationPathSeparator = "/"
+
+type LocationManager interface {
+	All() (LocationSlice, error)
+	View(int64) (Location, error)
+	FindByPath(string) (Location, error)
+	Create(CreateLocation) (Location, error)
+	Update(int64, CreateLocation) (Location, error)
+	Delete(int64) error
+}
+
+type locationManager struct {
+	client *ApiClient
+}
+
+func newLocationManager(client *ApiClient) locationManager {
+	return locationManager{
+		client,
+	}
+}
+
+type Location struct {
+	mgm.DefaultModel `bson:",inline" json:"-"`
+	ID               int64           `bson:"id" json:"id"`
+	Name             string          `bson:"name" json:"name"`
+	ParentLocationID int64           `bson:"parent_location_id" json:"parent_location_id"`
+	PrimaryContactID int64           `bson:"primary_contact_id" json:"primary_contact_id"`
+	Address          LocationAddress `bson:"address" json:"address"`
+	CreatedAt        *time.Time      `bson:"created_at" json:"created_at"`
+	UpdatedAt        *time.Time      `bson:"updated_at" json:"updated_at"`
+}
+
+type LocationAddress struct {
+	Line1   string `bson:"line1" json:"line1,omitempty"`
+	Line2   string `bson:"line2" json:"line2,omitempty"`
+	City    string `bson:"city" json:"city,omitempty"`
+	State   string `bson:"state" json:"state,omitempty"`
+	Country string `bson:"country" json:"country,omitempty"`
+	Zipcode string `bson:"zipcode" json:"zipcode,omitempty"`
+}
+
+type CreateLocation struct {
+	Name             string           `json:"name,omitempty"`
+	ParentLocationID int64            `json:"parent_location_id,omitempty"`
+	PrimaryContactID int64            `json:"primary_contact_id,omitempty"`
+	Address          *LocationAddress `json:"address,omitempty"`
+}
+
+type RespLocations struct {
+	Locations []Location `json:"locations,omitempty"`
+}
+
+type RespLocation struct {
+	Location Location `json:"location,omitempty"`
+}
+
+// LocationNode is a location with its child locations, as built by LocationSlice.Tree.
+type LocationNode struct {
+	Location Location
+	Children []*LocationNode
+}
+
+type LocationSlice []Location
+
+func (s LocationSlice) Len() int { return len(s) }
+
+func (s LocationSlice) Less(i, j int) bool { return s[i].ID < s[j].ID }
+
+func (s LocationSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s LocationSlice) Print() {
+	for _, location := range s {
+		fmt.Println(s.Path(location.ID))
+	}
+}
+
+// Find returns the location with the given ID.
+func (s LocationSlice) Find(id int64) (Location, bool) {
+	for _, location := range s {
+		if location.ID == id {
+			return location, true
+		}
+	}
+	return Location{}, false
+}
+
+// Children returns the direct children of the location, ordered by name.
+// An id of 0 returns the top level locations.
+func (s LocationSlice) Children(id int64) LocationSlice {
+	children := LocationSlice{}
+	for _, location := range s {
+		if location.ParentLocationID == id && location.ID != id {
+			children = append(children, location)
+		}
+	}
+	sort.SliceStable(children, func(i, j int) bool { return children[i].Name < children[j].Name })
+	return children
+}
+
+// Tree arranges the locations into their hierarchy. Locations whose parent is
+// not part of the slice are returned as roots.
+func (s LocationSlice) Tree() []*LocationNode {
+	nodes := make(map[int64]*LocationNode, len(s))
+	for _, location := range s {
+		nodes[location.ID] = &LocationNode{Location: location}
+	}
+	roots := []*LocationNode{}
+	for _, location := range s.sortedByName() {
+		node := nodes[location.ID]
+		parent, ok := nodes[location.ParentLocationID]
+		if !ok || location.ParentLocationID == location.ID || s.isAncestor(location.ID, location.ParentLocationID) {
+			roots = append(roots, node)
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+	}
+	return roots
+}
+
+// Ancestors returns the parents of the location, starting with its direct
+// parent and ending with the top level location.
+func (s LocationSlice) Ancestors(id int64) LocationSlice {
+	ancestors := LocationSlice{}
+	seen := map[int64]bool{id: true}
+	location, ok := s.Find(id)
+	for ok && location.ParentLocationID != 0 && !seen[location.ParentLocationID] {
+		seen[location.ParentLocationID] = true
+		location, ok = s.Find(location.ParentLocationID)
+		if ok {
+			ancestors = append(ancestors, location)
+		}
+	}
+	return ancestors
+}
+
+// Path returns the names from the top level location down to the location,
+// joined by LocationPathSeparator.
+func (s LocationSlice) Path(id int64) string {
+	location, ok := s.Find(id)
+	if !ok {
+		return ""
+	}
+	ancestors := s.Ancestors(id)
+	names := make([]string, 0, len(ancestors)+1)
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		names = append(names, ancestors[i].Name)
+	}
+	return strings.Join(append(names, location.Name), LocationPathSeparator)
+}
+
+// Resolve finds the location at a path such as "HQ/Floor 3/Lab", starting from
+// a top level location. Names are compared ignoring case. ErrNotFound is
+// returned if any segment does not exist.
+func (s LocationSlice) Resolve(path string) (Location, error) {
+	var current Location
+	parentID := int64(0)
+	for _, name := range strings.Split(strings.Trim(path, LocationPathSeparator), LocationPathSeparator) {
+		name = strings.TrimSpace(name)
+		found := false
+		for _, child := range s.Children(parentID) {
+			if strings.EqualFold(child.Name, name) {
+				current, found = child, true
+				break
+			}
+		}
+		if !found {
+			return Location{}, ErrNotFound
+		}
+		parentID = current.ID
+	}
+	return current, nil
+}
+
+func (s LocationSlice) isAncestor(ancestorID, id int64) bool {
+	for _, ancestor := range s.Ancestors(id) {
+		if ancestor.ID == ancestorID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s LocationSlice) sortedByName() LocationSlice {
+	sorted := append(LocationSlice{}, s...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	return sorted
+}
+
+func (manager locationManager) All() (LocationSlice, error) {
+	resp := RespLocations{}
+	output := LocationSlice{}
+	headers, err := manager.client.get(endpoints.locations.all, &resp)
+	if err != nil {
+		return LocationSlice{}, err
+	}
+	output = append(output, resp.Locations...)
+
+	for {
+		nextLink := manager.client.getNextLink(headers)
+		if nextLink == "" {
+			break
+		}
+		nextResp := RespLocations{}
+		headers, err = manager.client.get(nextLink, &nextResp)
+		if err != nil {
+			return LocationSlice{}, err
+		}
+		output = append(output, nextResp.Locations...)
+	}
+	return output, nil
+}
+
+func (manager locationManager) View(id int64) (Location, error) {
+	output := RespLocation{}
+	_, err := manager.client.get(endpoints.locations.view(id), &output)
+	if err != nil {
+		return Location{}, err
+	}
+	return output.Location, nil
+}
+
+// FindByPath fetches all locations and resolves the path with LocationSlice.Resolve.
+func (manager locationManager) FindByPath(path string) (Location, error) {
+	locations, err := manager.All()
+	if err != nil {
+		return Location{}, err
+	}
+	return locations.Resolve(path)
+}
+
+func (manager locationManager) Create(location CreateLocation) (Location, error) {
+	output := RespLocation{}
+	jsonb, err := json.Marshal(location)
+	if err != nil {
+		return Location{}, err
+	}
+	err = manager.client.postJSON(endpoints.locations.create, jsonb, &output, http.StatusCreated)
+	if err != nil {
+		return Location{}, err
+	}
+	return output.Location, nil
+}
+
+func (manager locationManager) Update(id int64, location CreateLocation) (Location, error) {
+	output := RespLocation{}
+	jsonb, err := json.Marshal(location)
+	if err != nil {
+		return Location{}, err
+	}
+	err = manager.client.put(endpoints.locations.update(id), jsonb, &output, http.StatusOK)
+	if err != nil {
+		return Location{}, err
+	}
+	return output.Location, nil
+}
+
+func (manager locationManager) Delete(id int64) error {
+	return manager.client.delete(endpoints.locations.delete(id), http.StatusNoContent)
+}
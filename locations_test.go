@@ -0,0 +1,150 @@
+package freshdesk
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testLocations holds a small hierarchy, a parent cycle between Loop A and
+// Loop B, a location whose parent is missing and one that is its own parent.
+var testLocations = LocationSlice{
+	{ID: 1, Name: "HQ"},
+	{ID: 2, Name: "Floor 3", ParentLocationID: 1},
+	{ID: 3, Name: "Lab", ParentLocationID: 2},
+	{ID: 4, Name: "Annex"},
+	{ID: 5, Name: "Loop A", ParentLocationID: 6},
+	{ID: 6, Name: "Loop B", ParentLocationID: 5},
+	{ID: 7, Name: "Orphan", ParentLocationID: 99},
+	{ID: 8, Name: "Self", ParentLocationID: 8},
+	{ID: 9, Name: "floor 1", ParentLocationID: 1},
+}
+
+func locationIDs(locations LocationSlice) []int64 {
+	ids := []int64{}
+	for _, location := range locations {
+		ids = append(ids, location.ID)
+	}
+	return ids
+}
+
+// renderTree writes the nodes as "Name(Child, Child)" for comparison.
+func renderTree(nodes []*LocationNode) string {
+	parts := []string{}
+	for _, node := range nodes {
+		part := node.Location.Name
+		if len(node.Children) > 0 {
+			part += "(" + renderTree(node.Children) + ")"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func TestLocationChildren(t *testing.T) {
+	tests := []struct {
+		id   int64
+		want []int64
+	}{
+		{0, []int64{4, 1}},
+		{1, []int64{2, 9}},
+		{2, []int64{3}},
+		{5, []int64{6}},
+		{8, []int64{}},
+		{42, []int64{}},
+	}
+	for _, test := range tests {
+		if got := locationIDs(testLocations.Children(test.id)); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Children(%d) = %v, want %v", test.id, got, test.want)
+		}
+	}
+}
+
+func TestLocationTree(t *testing.T) {
+	want := "Annex, HQ(Floor 3(Lab), floor 1), Loop A, Loop B, Orphan, Self"
+	if got := renderTree(testLocations.Tree()); got != want {
+		t.Errorf("Tree() = %s, want %s", got, want)
+	}
+	if got := renderTree(LocationSlice{}.Tree()); got != "" {
+		t.Errorf("empty Tree() = %s", got)
+	}
+}
+
+func TestLocationAncestors(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       int64
+		want     []int64
+		wantPath string
+	}{
+		{"nested", 3, []int64{2, 1}, "HQ/Floor 3/Lab"},
+		{"top level", 1, []int64{}, "HQ"},
+		{"cycle", 5, []int64{6}, "Loop B/Loop A"},
+		{"orphan", 7, []int64{}, "Orphan"},
+		{"own parent", 8, []int64{}, "Self"},
+		{"unknown", 42, []int64{}, ""},
+	}
+	for _, test := range tests {
+		if got := locationIDs(testLocations.Ancestors(test.id)); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: Ancestors(%d) = %v, want %v", test.name, test.id, got, test.want)
+		}
+		if got := testLocations.Path(test.id); got != test.wantPath {
+			t.Errorf("%s: Path(%d) = %q, want %q", test.name, test.id, got, test.wantPath)
+		}
+	}
+}
+
+func TestLocationResolve(t *testing.T) {
+	tests := []struct {
+		path   string
+		wantID int64
+	}{
+		{"HQ/Floor 3/Lab", 3},
+		{"hq/FLOOR 3/lab", 3},
+		{"/HQ/ Floor 3 /", 2},
+		{"Annex", 4},
+		{"HQ/Lab", 0},
+		{"Orphan", 0},
+		{"Loop A", 0},
+		{"Self", 0},
+		{"", 0},
+	}
+	for _, test := range tests {
+		location, err := testLocations.Resolve(test.path)
+		if test.wantID == 0 {
+			if err != ErrNotFound {
+				t.Errorf("Resolve(%q) = %d, %v, want ErrNotFound", test.path, location.ID, err)
+			}
+			continue
+		}
+		if err != nil || location.ID != test.wantID {
+			t.Errorf("Resolve(%q) = %d, %v, want %d", test.path, location.ID, err, test.wantID)
+		}
+	}
+}
+
+func TestLocationFindByPath(t *testing.T) {
+	body := `{"locations": [{"id": 1, "name": "HQ"}, {"id": 2, "name": "Floor 3", "parent_location_id": 1}]}`
+	tests := []struct {
+		name     string
+		response http.HandlerFunc
+		path     string
+		wantID   int64
+		wantErr  string
+	}{
+		{"found", respond(http.StatusOK, body), "HQ/Floor 3", 2, ""},
+		{"not found", respond(http.StatusOK, body), "HQ/Floor 4", 0, ErrNotFound.Error()},
+		{"server error", respond(http.StatusInternalServerError, ``), "HQ", 0, "received status code 500 (2xx expected)"},
+	}
+	for _, test := range tests {
+		location, err := testClient(test.response).Locations.FindByPath(test.path)
+		gotErr := ""
+		if err != nil {
+			gotErr = err.Error()
+		}
+		if gotErr != test.wantErr || location.ID != test.wantID {
+			t.Errorf("%s: FindByPath() = %d, %q, want %d, %q", test.name, location.ID, gotErr, test.wantID, test.wantErr)
+		}
+	}
+}
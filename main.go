@@ -12,14 +12,30 @@ type ApiClient struct {
 	domain          string
 	apiKey          string
 	logger          *log.Logger
+	transport       http.RoundTripper
 	Departments     DepartmentManager
 	Requesters      RequesterManager
+	Agents          AgentManager
+	Groups          GroupManager
+	RequesterGroups RequesterGroupManager
+	Locations       LocationManager
+	Assets          AssetManager
+	AssetTypes      AssetTypeManager
+	Relationships   RelationshipManager
+	Changes         ChangeManager
+	Problems        ProblemManager
+	Releases        ReleaseManager
+	Projects        ProjectManager
 	Tickets         TicketManager
+	TicketFields    TicketFieldManager
 	ServiceRequests ServiceRequestManager
 }
 
 type ClientOptions struct {
 	Logger *log.Logger
+	// Transport sends the requests, e.g. through a proxy. Nil uses
+	// http.DefaultTransport.
+	Transport http.RoundTripper
 }
 
 func EmptyOptions() *ClientOptions {
@@ -34,13 +50,26 @@ func Init(domain, apiKey string, options *ClientOptions) ApiClient {
 	}
 	if options != nil {
 		client.logger = options.Logger
+		client.transport = options.Transport
 	}
 	if client.logger != nil {
 		client.logger.Println("Freshservice Client initializing... Domain =", domain, "authorization =", apiKey)
 	}
 	client.Departments = newDepartmentManager(&client)
 	client.Tickets = newTicketManager(&client)
+	client.TicketFields = newTicketFieldManager(&client)
 	client.Requesters = newrequesterManager(&client)
+	client.Agents = newAgentManager(&client)
+	client.Groups = newGroupManager(&client)
+	client.RequesterGroups = newRequesterGroupManager(&client)
+	client.Locations = newLocationManager(&client)
+	client.Assets = newAssetManager(&client)
+	client.AssetTypes = newAssetTypeManager(&client)
+	client.Relationships = newRelationshipManager(&client)
+	client.Changes = newChangeManager(&client)
+	client.Problems = newProblemManager(&client)
+	client.Releases = newReleaseManager(&client)
+	client.Projects = newProjectManager(&client)
 	client.ServiceRequests = newServiceRequestManager(&client)
 	return client
 }
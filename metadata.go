@@ -0,0 +1,139 @@
+package freshdesk
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Choices maps the numeric values of a ticket property to their display names.
+type Choices map[int]string
+
+// Name returns the display name of the value, or the number itself if unknown.
+func (c Choices) Name(value int) string {
+	if name, ok := c[value]; ok {
+		return name
+	}
+	return strconv.Itoa(value)
+}
+
+// Value returns the value with the given display name, ignoring case.
+func (c Choices) Value(name string) (int, bool) {
+	for value, choice := range c {
+		if strings.EqualFold(choice, name) {
+			return value, true
+		}
+	}
+	return 0, false
+}
+
+func (c Choices) Valid(value int) bool {
+	_, ok := c[value]
+	return ok
+}
+
+// Values returns the known values in ascending order.
+func (c Choices) Values() []int {
+	values := make([]int, 0, len(c))
+	for value := range c {
+		values = append(values, value)
+	}
+	sort.Ints(values)
+	return values
+}
+
+func (c Choices) copy() Choices {
+	copied := make(Choices, len(c))
+	for value, name := range c {
+		copied[value] = name
+	}
+	return copied
+}
+
+// TicketMetadata holds the ticket property choices configured for an account.
+// Accounts may add custom statuses, so prefer it over the built-in constants
+// when rendering or validating tickets.
+type TicketMetadata struct {
+	Statuses   Choices
+	Priorities Choices
+	Sources    Choices
+	Urgencies  Choices
+	Impacts    Choices
+}
+
+// DefaultTicketMetadata returns the choices of the built-in constants, used
+// when an account's form does not list a property.
+func DefaultTicketMetadata() TicketMetadata {
+	return TicketMetadata{
+		Statuses:   statusNames.copy(),
+		Priorities: priorityNames.copy(),
+		Sources:    sourceNames.copy(),
+		Urgencies:  Choices{1: "Low", 2: "Medium", 3: "High"},
+		Impacts:    Choices{1: "Low", 2: "Medium", 3: "High"},
+	}
+}
+
+// NewTicketMetadata reads the property choices from the ticket form fields,
+// keeping the defaults for properties the form does not define.
+func NewTicketMetadata(fields TicketFieldSlice) TicketMetadata {
+	metadata := DefaultTicketMetadata()
+	properties := map[string]*Choices{
+		"status":   &metadata.Statuses,
+		"priority": &metadata.Priorities,
+		"source":   &metadata.Sources,
+		"urgency":  &metadata.Urgencies,
+		"impact":   &metadata.Impacts,
+	}
+	for _, field := range fields {
+		// A custom field may share a property's name; only the default field
+		// holds the property's choices.
+		choices, ok := properties[field.Name]
+		if !ok || !field.DefaultField || len(field.Choices) == 0 {
+			continue
+		}
+		loaded := Choices{}
+		for _, choice := range field.Choices {
+			loaded[int(choice.ID)] = choice.Value
+		}
+		*choices = loaded
+	}
+	return metadata
+}
+
+// Validate checks the ticket properties against the account's choices and
+// returns ValidationErrors for every unknown value. Unset properties are skipped.
+func (metadata TicketMetadata) Validate(ticket CreateTicket) error {
+	errs := ValidationErrors{}
+	check := func(field string, value int, choices Choices) {
+		if value != 0 && !choices.Valid(value) {
+			errs = append(errs, TicketFieldError{field, fmt.Sprintf("%d is not a valid %s", value, field)})
+		}
+	}
+	check("status", ticket.Status.Value(), metadata.Statuses)
+	check("priority", ticket.Priority.Value(), metadata.Priorities)
+	check("source", ticket.Source.Value(), metadata.Sources)
+	check("impact", int(ticket.Impact), metadata.Impacts)
+	if ticket.Urgency != "" {
+		urgency, err := strconv.Atoi(ticket.Urgency)
+		if err != nil {
+			urgency, _ = metadata.Urgencies.Value(ticket.Urgency)
+		}
+		if !metadata.Urgencies.Valid(urgency) {
+			errs = append(errs, TicketFieldError{"urgency", fmt.Sprintf("%s is not a valid urgency", ticket.Urgency)})
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Metadata loads the account's ticket property choices from the form fields.
+func (manager ticketFieldManager) Metadata() (TicketMetadata, error) {
+	fields, err := manager.All()
+	if err != nil {
+		return TicketMetadata{}, err
+	}
+	return NewTicketMetadata(fields), nil
+}
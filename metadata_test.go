@@ -0,0 +1,90 @@
+package freshdesk
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChoices(t *testing.T) {
+	choices := Choices{2: "Open", 6: "Awaiting vendor", 3: "Pending"}
+	if got := choices.Name(6); got != "Awaiting vendor" {
+		t.Errorf("Name(6) = %q", got)
+	}
+	if got := choices.Name(9); got != "9" {
+		t.Errorf("Name(9) = %q", got)
+	}
+	if got, ok := choices.Value("awaiting VENDOR"); !ok || got != 6 {
+		t.Errorf("Value() = %d, %v", got, ok)
+	}
+	if _, ok := choices.Value("Closed"); ok {
+		t.Error("Value(Closed) found")
+	}
+	if !choices.Valid(3) || choices.Valid(4) {
+		t.Error("Valid() wrong")
+	}
+	if got := choices.Values(); !reflect.DeepEqual(got, []int{2, 3, 6}) {
+		t.Errorf("Values() = %v", got)
+	}
+}
+
+func TestDefaultTicketMetadata(t *testing.T) {
+	metadata := DefaultTicketMetadata()
+	if got := metadata.Statuses.Name(StatusPending.Value()); got != "Pending" {
+		t.Errorf("default status name = %q", got)
+	}
+	metadata.Statuses[StatusPending.Value()] = "Waiting"
+	if got := StatusPending.String(); got != "Pending" {
+		t.Errorf("changing the metadata renamed the constant to %q", got)
+	}
+}
+
+func TestNewTicketMetadata(t *testing.T) {
+	fields := TicketFieldSlice{
+		{Name: "status", DefaultField: true, Choices: []TicketFieldChoice{
+			{ID: 2, Value: "Open"}, {ID: 5, Value: "Closed"}, {ID: 6, Value: "Awaiting vendor"},
+		}},
+		{Name: "priority", DefaultField: true},
+		{Name: "impact", DefaultField: true, Choices: []TicketFieldChoice{{ID: 1, Value: "Team"}, {ID: 2, Value: "Company"}}},
+	}
+	metadata := NewTicketMetadata(fields)
+	defaults := DefaultTicketMetadata()
+	if want := (Choices{2: "Open", 5: "Closed", 6: "Awaiting vendor"}); !reflect.DeepEqual(metadata.Statuses, want) {
+		t.Errorf("Statuses = %v, want %v", metadata.Statuses, want)
+	}
+	if want := (Choices{1: "Team", 2: "Company"}); !reflect.DeepEqual(metadata.Impacts, want) {
+		t.Errorf("Impacts = %v, want %v", metadata.Impacts, want)
+	}
+	if !reflect.DeepEqual(metadata.Priorities, defaults.Priorities) {
+		t.Errorf("priority without choices = %v, want the defaults", metadata.Priorities)
+	}
+	if !reflect.DeepEqual(metadata.Sources, defaults.Sources) || !reflect.DeepEqual(metadata.Urgencies, defaults.Urgencies) {
+		t.Error("properties missing from the form lost their defaults")
+	}
+}
+
+func TestTicketMetadataValidate(t *testing.T) {
+	metadata := DefaultTicketMetadata()
+	metadata.Statuses[6] = "Awaiting vendor"
+	tests := []struct {
+		name   string
+		ticket CreateTicket
+		want   string
+	}{
+		{"unset", CreateTicket{}, ""},
+		{"valid", CreateTicket{Status: StatusOpen, Priority: PriorityHigh, Source: Source(2), Impact: 3, Urgency: "2"}, ""},
+		{"custom status", CreateTicket{Status: Status(6)}, ""},
+		{"unknown status", CreateTicket{Status: Status(7)}, "status"},
+		{"unknown priority", CreateTicket{Priority: Priority(5)}, "priority"},
+		{"unknown source", CreateTicket{Source: Source(99)}, "source"},
+		{"unknown impact", CreateTicket{Impact: 4}, "impact"},
+		{"urgency name", CreateTicket{Urgency: "high"}, ""},
+		{"unknown urgency name", CreateTicket{Urgency: "Critical"}, "urgency"},
+		{"unknown urgency value", CreateTicket{Urgency: "4"}, "urgency"},
+		{"several", CreateTicket{Status: Status(7), Impact: 4, Urgency: "0"}, "impact,status,urgency"},
+	}
+	for _, test := range tests {
+		if got := fieldNames(metadata.Validate(test.ticket)); got != test.want {
+			t.Errorf("%s: errors on %q, want %q", test.name, got, test.want)
+		}
+	}
+}
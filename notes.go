@@ -0,0 +1,53 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Notes on changes, problems and releases share the shape of ticket
+// conversations and are returned as Conversation values.
+type RespNotes struct {
+	Notes []Conversation `json:"notes,omitempty"`
+}
+
+type RespNote struct {
+	Note Conversation `json:"note,omitempty"`
+}
+
+func (c *ApiClient) getNotes(path string) (ConversationSlice, error) {
+	resp := RespNotes{}
+	output := ConversationSlice{}
+	headers, err := c.get(path, &resp)
+	if err != nil {
+		return ConversationSlice{}, err
+	}
+	output = append(output, resp.Notes...)
+
+	for {
+		nextLink := c.getNextLink(headers)
+		if nextLink == "" {
+			break
+		}
+		nextResp := RespNotes{}
+		headers, err = c.get(nextLink, &nextResp)
+		if err != nil {
+			return ConversationSlice{}, err
+		}
+		output = append(output, nextResp.Notes...)
+	}
+	return output, nil
+}
+
+func (c *ApiClient) postNote(path string, note CreateConversation) (Conversation, error) {
+	output := RespNote{}
+	jsonb, err := json.Marshal(note)
+	if err != nil {
+		return Conversation{}, err
+	}
+	err = c.postJSON(path, jsonb, &output, http.StatusCreated)
+	if err != nil {
+		return Conversation{}, err
+	}
+	return output.Note, nil
+}
@@ -0,0 +1,44 @@
+package freshdesk
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Bool returns a pointer to v, for the optional fields of update payloads.
+func Bool(v bool) *bool { return &v }
+
+// String returns a pointer to v, for the optional fields of update payloads.
+func String(v string) *string { return &v }
+
+// Int64 returns a pointer to v, for the optional fields of update payloads.
+func Int64(v int64) *int64 { return &v }
+
+// NullInt64 is a nullable ID of an update payload. A nil *NullInt64 leaves
+// the field unchanged, NullableInt64 sets it and Null clears it.
+type NullInt64 struct {
+	Int64 int64
+	Valid bool
+}
+
+// NullableInt64 returns a NullInt64 setting the field to v.
+func NullableInt64(v int64) *NullInt64 { return &NullInt64{Int64: v, Valid: true} }
+
+// Null returns a NullInt64 clearing the field.
+func Null() *NullInt64 { return &NullInt64{} }
+
+func (n NullInt64) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.Int64)
+}
+
+func (n *NullInt64) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*n = NullInt64{}
+		return nil
+	}
+	n.Valid = true
+	return json.Unmarshal(data, &n.Int64)
+}
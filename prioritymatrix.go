@@ -0,0 +1,118 @@
+package freshdesk
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Level is an urgency or impact level used by the priority matrix.
+type Level int
+
+const (
+	LevelLow Level = 1 + iota
+	LevelMedium
+	LevelHigh
+)
+
+// PriorityMatrix derives a ticket's priority from its urgency and impact.
+type PriorityMatrix struct {
+	priorities map[[2]Level]Priority
+}
+
+// PriorityDeviation is a ticket whose priority differs from the matrix.
+type PriorityDeviation struct {
+	TicketID int64
+	Urgency  Level
+	Impact   Level
+	Actual   Priority
+	Expected Priority
+}
+
+func (d PriorityDeviation) String() string {
+	return fmt.Sprintf("ticket %d: priority %s, expected %s for urgency %d and impact %d",
+		d.TicketID, d.Actual, d.Expected, d.Urgency, d.Impact)
+}
+
+// DefaultPriorityMatrix returns the ITIL 3x3 matrix.
+//
+//	impact \ urgency   low      medium   high
+//	low                Low      Low      Medium
+//	medium             Low      Medium   High
+//	high               Medium   High     Urgent
+func DefaultPriorityMatrix() PriorityMatrix {
+	matrix := PriorityMatrix{priorities: map[[2]Level]Priority{}}
+	table := [3][3]Priority{
+		{PriorityLow, PriorityLow, PriorityMedium},
+		{PriorityLow, PriorityMedium, PriorityHigh},
+		{PriorityMedium, PriorityHigh, PriorityUrgent},
+	}
+	for impact := range table {
+		for urgency, priority := range table[impact] {
+			matrix.priorities[[2]Level{Level(urgency + 1), Level(impact + 1)}] = priority
+		}
+	}
+	return matrix
+}
+
+// Override sets the priority for an urgency and impact, e.g. to mirror the
+// account's priority matrix settings. It returns a modified copy, leaving the
+// matrix it is called on unchanged, so calls can be chained.
+func (matrix PriorityMatrix) Override(urgency, impact Level, priority Priority) PriorityMatrix {
+	priorities := make(map[[2]Level]Priority, len(matrix.priorities)+1)
+	for key, value := range matrix.priorities {
+		priorities[key] = value
+	}
+	priorities[[2]Level{urgency, impact}] = priority
+	return PriorityMatrix{priorities: priorities}
+}
+
+// Priority returns the priority for the urgency and impact, if defined.
+func (matrix PriorityMatrix) Priority(urgency, impact Level) (Priority, bool) {
+	priority, ok := matrix.priorities[[2]Level{urgency, impact}]
+	return priority, ok
+}
+
+// Apply sets the priority of the ticket from its urgency and impact.
+func (matrix PriorityMatrix) Apply(ticket *CreateTicket) error {
+	urgency, ok := parseUrgency(ticket.Urgency)
+	if !ok {
+		return fmt.Errorf("invalid urgency %q", ticket.Urgency)
+	}
+	priority, ok := matrix.Priority(urgency, Level(ticket.Impact))
+	if !ok {
+		return fmt.Errorf("no priority defined for urgency %d and impact %d", urgency, ticket.Impact)
+	}
+	ticket.Priority = priority
+	return nil
+}
+
+// Lint returns the tickets whose priority deviates from the matrix. Tickets
+// without a valid urgency and impact are skipped.
+func (matrix PriorityMatrix) Lint(tickets TicketSlice) []PriorityDeviation {
+	deviations := []PriorityDeviation{}
+	for _, ticket := range tickets {
+		urgency, ok := parseUrgency(ticket.Urgency)
+		if !ok {
+			continue
+		}
+		expected, ok := matrix.Priority(urgency, Level(ticket.Impact))
+		if !ok || expected == ticket.Priority {
+			continue
+		}
+		deviations = append(deviations, PriorityDeviation{
+			TicketID: ticket.ID,
+			Urgency:  urgency,
+			Impact:   Level(ticket.Impact),
+			Actual:   ticket.Priority,
+			Expected: expected,
+		})
+	}
+	sort.Slice(deviations, func(i, j int) bool { return deviations[i].TicketID < deviations[j].TicketID })
+	return deviations
+}
+
+// parseUrgency reads Ticket.Urgency, which holds either the level or its name.
+func parseUrgency(urgency string) (Level, bool) {
+	value, err := parseChoice("urgency", urgency, DefaultTicketMetadata().Urgencies)
+	return Level(value), err == nil && value > 0
+}
@@ -0,0 +1,96 @@
+package freshdesk
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDefaultPriorityMatrix(t *testing.T) {
+	matrix := DefaultPriorityMatrix()
+	tests := []struct {
+		urgency, impact Level
+		want            Priority
+	}{
+		{LevelLow, LevelLow, PriorityLow},
+		{LevelMedium, LevelLow, PriorityLow},
+		{LevelHigh, LevelLow, PriorityMedium},
+		{LevelLow, LevelMedium, PriorityLow},
+		{LevelMedium, LevelMedium, PriorityMedium},
+		{LevelHigh, LevelMedium, PriorityHigh},
+		{LevelLow, LevelHigh, PriorityMedium},
+		{LevelMedium, LevelHigh, PriorityHigh},
+		{LevelHigh, LevelHigh, PriorityUrgent},
+	}
+	for _, test := range tests {
+		got, ok := matrix.Priority(test.urgency, test.impact)
+		if !ok || got != test.want {
+			t.Errorf("Priority(%d, %d) = %s, %v, want %s", test.urgency, test.impact, got, ok, test.want)
+		}
+	}
+	if _, ok := matrix.Priority(0, LevelLow); ok {
+		t.Error("Priority(0, low) defined")
+	}
+}
+
+func TestPriorityMatrixOverride(t *testing.T) {
+	base := DefaultPriorityMatrix()
+	custom := base.Override(LevelHigh, LevelHigh, PriorityHigh).Override(LevelLow, LevelLow, PriorityMedium)
+	if got, _ := custom.Priority(LevelHigh, LevelHigh); got != PriorityHigh {
+		t.Errorf("overridden priority = %s", got)
+	}
+	if got, _ := custom.Priority(LevelLow, LevelLow); got != PriorityMedium {
+		t.Errorf("second override = %s", got)
+	}
+	if got, _ := base.Priority(LevelHigh, LevelHigh); got != PriorityUrgent {
+		t.Errorf("Override changed the original matrix: %s", got)
+	}
+	if got, ok := (PriorityMatrix{}).Override(LevelLow, LevelLow, PriorityLow).Priority(LevelLow, LevelLow); !ok || got != PriorityLow {
+		t.Errorf("Override on a zero matrix = %s, %v", got, ok)
+	}
+}
+
+func TestPriorityMatrixApply(t *testing.T) {
+	matrix := DefaultPriorityMatrix()
+	tests := []struct {
+		ticket  CreateTicket
+		want    Priority
+		wantErr bool
+	}{
+		{CreateTicket{Urgency: "3", Impact: 2}, PriorityHigh, false},
+		{CreateTicket{Urgency: "High", Impact: 3}, PriorityUrgent, false},
+		{CreateTicket{Urgency: "", Impact: 2}, 0, true},
+		{CreateTicket{Urgency: "Critical", Impact: 2}, 0, true},
+		{CreateTicket{Urgency: "Low", Impact: 0}, 0, true},
+	}
+	for _, test := range tests {
+		ticket := test.ticket
+		err := matrix.Apply(&ticket)
+		if (err != nil) != test.wantErr {
+			t.Errorf("Apply(%q, %d) error = %v", test.ticket.Urgency, test.ticket.Impact, err)
+			continue
+		}
+		if !test.wantErr && ticket.Priority != test.want {
+			t.Errorf("Apply(%q, %d) priority = %s, want %s", test.ticket.Urgency, test.ticket.Impact, ticket.Priority, test.want)
+		}
+	}
+}
+
+func TestPriorityMatrixLint(t *testing.T) {
+	tickets := TicketSlice{
+		{ID: 3, Urgency: "High", Impact: 3, Priority: PriorityLow},
+		{ID: 1, Urgency: "1", Impact: 1, Priority: PriorityLow},
+		{ID: 2, Urgency: "2", Impact: 3, Priority: PriorityUrgent},
+		{ID: 4, Urgency: "", Impact: 3, Priority: PriorityLow},
+		{ID: 5, Urgency: "Low", Impact: 0, Priority: PriorityUrgent},
+	}
+	want := []PriorityDeviation{
+		{TicketID: 2, Urgency: LevelMedium, Impact: LevelHigh, Actual: PriorityUrgent, Expected: PriorityHigh},
+		{TicketID: 3, Urgency: LevelHigh, Impact: LevelHigh, Actual: PriorityLow, Expected: PriorityUrgent},
+	}
+	if got := DefaultPriorityMatrix().Lint(tickets); !reflect.DeepEqual(got, want) {
+		t.Errorf("Lint() = %v, want %v", got, want)
+	}
+	if got := DefaultPriorityMatrix().Lint(nil); len(got) != 0 {
+		t.Errorf("Lint(nil) = %v", got)
+	}
+}
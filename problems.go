@@ -0,0 +1,226 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/nextlinktechnology/mgm/v3"
+)
+
+type ProblemManager interface {
+	All() (ProblemSlice, error)
+	View(int64) (Problem, error)
+	Create(CreateProblem) (Problem, error)
+	Update(int64, CreateProblem) (Problem, error)
+	Delete(int64) error
+	Notes(int64) (ConversationSlice, error)
+	AddNote(int64, CreateConversation) (Conversation, error)
+	Tasks(int64) (TaskSlice, error)
+	CreateTask(int64, CreateTask) (Task, error)
+	UpdateTask(int64, int64, CreateTask) (Task, error)
+	DeleteTask(int64, int64) error
+	AssociateTickets(int64, ...int64) error
+}
+
+type problemManager struct {
+	client *ApiClient
+}
+
+func newProblemManager(client *ApiClient) problemManager {
+	return problemManager{
+		client,
+	}
+}
+
+type Problem struct {
+	mgm.DefaultModel `bson:",inline" json:"-"`
+	ID               int64                  `bson:"id" json:"id"`
+	RequesterID      int64                  `bson:"requester_id" json:"requester_id"`
+	AgentID          int64                  `bson:"agent_id" json:"agent_id"`
+	GroupID          int64                  `bson:"group_id" json:"group_id"`
+	DepartmentID     int64                  `bson:"department_id" json:"department_id"`
+	Subject          string                 `bson:"subject" json:"subject"`
+	Description      string                 `bson:"description" json:"description"`
+	DescriptionText  string                 `bson:"description_text" json:"description_text"`
+	DueBy            *time.Time             `bson:"due_by" json:"due_by"`
+	Priority         int                    `bson:"priority" json:"priority"`
+	Status           int                    `bson:"status" json:"status"`
+	Impact           int                    `bson:"impact" json:"impact"`
+	KnownError       bool                   `bson:"known_error" json:"known_error"`
+	Category         string                 `bson:"category" json:"category"`
+	SubCategory      string                 `bson:"sub_category" json:"sub_category"`
+	ItemCategory     string                 `bson:"item_category" json:"item_category"`
+	AnalysisFields   ProblemAnalysisFields  `bson:"analysis_fields" json:"analysis_fields"`
+	Attachments      []interface{}          `bson:"attachments" json:"attachments"`
+	CustomFields     map[string]interface{} `bson:"custom_fields" json:"custom_fields"`
+	CreatedAt        *time.Time             `bson:"created_at" json:"created_at"`
+	UpdatedAt        *time.Time             `bson:"updated_at" json:"updated_at"`
+}
+
+// ProblemAnalysisFields holds the root cause analysis section of a problem.
+type ProblemAnalysisFields struct {
+	ProblemCause   *PlanningField `bson:"problem_cause" json:"problem_cause,omitempty"`
+	ProblemSymptom *PlanningField `bson:"problem_symptom" json:"problem_symptom,omitempty"`
+	ProblemImpact  *PlanningField `bson:"problem_impact" json:"problem_impact,omitempty"`
+}
+
+type CreateProblem struct {
+	RequesterID    int64                  `json:"requester_id,omitempty"`
+	Email          string                 `json:"email,omitempty"`
+	AgentID        int64                  `json:"agent_id,omitempty"`
+	GroupID        int64                  `json:"group_id,omitempty"`
+	DepartmentID   int64                  `json:"department_id,omitempty"`
+	Subject        string                 `json:"subject,omitempty"`
+	Description    string                 `json:"description,omitempty"`
+	DueBy          *time.Time             `json:"due_by,omitempty"`
+	Priority       int                    `json:"priority,omitempty"`
+	Status         int                    `json:"status,omitempty"`
+	Impact         int                    `json:"impact,omitempty"`
+	KnownError     bool                   `json:"known_error,omitempty"`
+	Category       string                 `json:"category,omitempty"`
+	SubCategory    string                 `json:"sub_category,omitempty"`
+	ItemCategory   string                 `json:"item_category,omitempty"`
+	AnalysisFields *ProblemAnalysisFields `json:"analysis_fields,omitempty"`
+	Attachments    []interface{}          `json:"attachments,omitempty"`
+	CustomFields   map[string]interface{} `json:"custom_fields,omitempty"`
+}
+
+type ProblemStatus int
+
+const (
+	ProblemStatusOpen ProblemStatus = 1 + iota
+	ProblemStatusChangeRequested
+	ProblemStatusClosed
+)
+
+func (s ProblemStatus) Value() int {
+	return int(s)
+}
+
+type RespProblems struct {
+	Problems []Problem `json:"problems,omitempty"`
+}
+
+type RespProblem struct {
+	Problem Problem `json:"problem,omitempty"`
+}
+
+func (p Problem) Print() {
+	jsonb, _ := json.MarshalIndent(p, "", "    ")
+	fmt.Println(string(jsonb))
+}
+
+type ProblemSlice []Problem
+
+func (s ProblemSlice) Len() int { return len(s) }
+
+func (s ProblemSlice) Less(i, j int) bool { return s[i].ID < s[j].ID }
+
+func (s ProblemSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s ProblemSlice) Print() {
+	for _, problem := range s {
+		fmt.Println(problem.Subject)
+	}
+}
+
+func (manager problemManager) All() (ProblemSlice, error) {
+	resp := RespProblems{}
+	output := ProblemSlice{}
+	headers, err := manager.client.get(endpoints.problems.all, &resp)
+	if err != nil {
+		return ProblemSlice{}, err
+	}
+	output = append(output, resp.Problems...)
+
+	for {
+		nextLink := manager.client.getNextLink(headers)
+		if nextLink == "" {
+			break
+		}
+		nextResp := RespProblems{}
+		headers, err = manager.client.get(nextLink, &nextResp)
+		if err != nil {
+			return ProblemSlice{}, err
+		}
+		output = append(output, nextResp.Problems...)
+	}
+	return output, nil
+}
+
+func (manager problemManager) View(id int64) (Problem, error) {
+	output := RespProblem{}
+	_, err := manager.client.get(endpoints.problems.view(id), &output)
+	if err != nil {
+		return Problem{}, err
+	}
+	return output.Problem, nil
+}
+
+func (manager problemManager) Create(problem CreateProblem) (Problem, error) {
+	output := RespProblem{}
+	jsonb, err := json.Marshal(problem)
+	if err != nil {
+		return Problem{}, err
+	}
+	err = manager.client.postJSON(endpoints.problems.create, jsonb, &output, http.StatusCreated)
+	if err != nil {
+		return Problem{}, err
+	}
+	return output.Problem, nil
+}
+
+func (manager problemManager) Update(id int64, problem CreateProblem) (Problem, error) {
+	output := RespProblem{}
+	jsonb, err := json.Marshal(problem)
+	if err != nil {
+		return Problem{}, err
+	}
+	err = manager.client.put(endpoints.problems.update(id), jsonb, &output, http.StatusOK)
+	if err != nil {
+		return Problem{}, err
+	}
+	return output.Problem, nil
+}
+
+func (manager problemManager) Delete(id int64) error {
+	return manager.client.delete(endpoints.problems.delete(id), http.StatusNoContent)
+}
+
+func (manager problemManager) Notes(id int64) (ConversationSlice, error) {
+	return manager.client.getNotes(endpoints.problems.notes(id))
+}
+
+func (manager problemManager) AddNote(id int64, note CreateConversation) (Conversation, error) {
+	return manager.client.postNote(endpoints.problems.notes(id), note)
+}
+
+func (manager problemManager) Tasks(id int64) (TaskSlice, error) {
+	return manager.client.getTasks(endpoints.problems.tasks(id))
+}
+
+func (manager problemManager) CreateTask(id int64, task CreateTask) (Task, error) {
+	return manager.client.postTask(endpoints.problems.tasks(id), task)
+}
+
+func (manager problemManager) UpdateTask(id, taskID int64, task CreateTask) (Task, error) {
+	return manager.client.putTask(endpoints.problems.task(id, taskID), task)
+}
+
+func (manager problemManager) DeleteTask(id, taskID int64) error {
+	return manager.client.delete(endpoints.problems.task(id, taskID), http.StatusNoContent)
+}
+
+// AssociateTickets links each ticket to the problem. It stops at the first
+// ticket that cannot be updated.
+func (manager problemManager) AssociateTickets(id int64, ticketIDs ...int64) error {
+	tickets := newTicketManager(manager.client)
+	for _, ticketID := range ticketIDs {
+		if _, err := tickets.AssociateProblem(ticketID, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
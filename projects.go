@@ -0,0 +1,275 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/nextlinktechnology/mgm/v3"
+)
+
+type ProjectManager interface {
+	All() (ProjectSlice, error)
+	View(int64) (Project, error)
+	Create(CreateProject) (Project, error)
+	Update(int64, CreateProject) (Project, error)
+	Delete(int64) error
+	Tasks(int64) (ProjectTaskSlice, error)
+	ViewTask(int64, int64) (ProjectTask, error)
+	CreateTask(int64, CreateProjectTask) (ProjectTask, error)
+	UpdateTask(int64, int64, CreateProjectTask) (ProjectTask, error)
+	DeleteTask(int64, int64) error
+}
+
+type projectManager struct {
+	client *ApiClient
+}
+
+func newProjectManager(client *ApiClient) projectManager {
+	return projectManager{
+		client,
+	}
+}
+
+type Project struct {
+	mgm.DefaultModel `bson:",inline" json:"-"`
+	ID               int64                  `bson:"id" json:"id"`
+	Name             string                 `bson:"name" json:"name"`
+	Key              string                 `bson:"key" json:"key"`
+	Description      string                 `bson:"description" json:"description"`
+	StatusID         int64                  `bson:"status_id" json:"status_id"`
+	PriorityID       int64                  `bson:"priority_id" json:"priority_id"`
+	SensitivityID    int64                  `bson:"sensitivity_id" json:"sensitivity_id"`
+	ManagerID        int64                  `bson:"manager_id" json:"manager_id"`
+	StartDate        string                 `bson:"start_date" json:"start_date"`
+	EndDate          string                 `bson:"end_date" json:"end_date"`
+	Archived         bool                   `bson:"archived" json:"archived"`
+	Visibility       int                    `bson:"visibility" json:"visibility"`
+	ProjectType      int                    `bson:"project_type" json:"project_type"`
+	CustomFields     map[string]interface{} `bson:"custom_fields" json:"custom_fields"`
+	CreatedAt        *time.Time             `bson:"created_at" json:"created_at"`
+	UpdatedAt        *time.Time             `bson:"updated_at" json:"updated_at"`
+}
+
+// CreateProject is the payload for creating or updating a project. Dates are
+// formatted as "2006-01-02".
+type CreateProject struct {
+	Name          string                 `json:"name,omitempty"`
+	Key           string                 `json:"key,omitempty"`
+	Description   string                 `json:"description,omitempty"`
+	StatusID      int64                  `json:"status_id,omitempty"`
+	PriorityID    int64                  `json:"priority_id,omitempty"`
+	SensitivityID int64                  `json:"sensitivity_id,omitempty"`
+	ManagerID     int64                  `json:"manager_id,omitempty"`
+	StartDate     string                 `json:"start_date,omitempty"`
+	EndDate       string                 `json:"end_date,omitempty"`
+	Visibility    int                    `json:"visibility,omitempty"`
+	ProjectType   int                    `json:"project_type,omitempty"`
+	CustomFields  map[string]interface{} `json:"custom_fields,omitempty"`
+}
+
+// ProjectTask is a work item of a project. Unlike Task it may be nested under
+// another project task through ParentID.
+type ProjectTask struct {
+	ID               int64                  `bson:"id" json:"id"`
+	DisplayKey       string                 `bson:"display_key" json:"display_key"`
+	Title            string                 `bson:"title" json:"title"`
+	Description      string                 `bson:"description" json:"description"`
+	StatusID         int64                  `bson:"status_id" json:"status_id"`
+	PriorityID       int64                  `bson:"priority_id" json:"priority_id"`
+	TypeID           int64                  `bson:"type_id" json:"type_id"`
+	AssigneeID       int64                  `bson:"assignee_id" json:"assignee_id"`
+	ReporterID       int64                  `bson:"reporter_id" json:"reporter_id"`
+	ParentID         int64                  `bson:"parent_id" json:"parent_id"`
+	PlannedStartDate string                 `bson:"planned_start_date" json:"planned_start_date"`
+	PlannedEndDate   string                 `bson:"planned_end_date" json:"planned_end_date"`
+	PlannedEffort    string                 `bson:"planned_effort" json:"planned_effort"`
+	CustomFields     map[string]interface{} `bson:"custom_fields" json:"custom_fields"`
+	CreatedAt        *time.Time             `bson:"created_at" json:"created_at"`
+	UpdatedAt        *time.Time             `bson:"updated_at" json:"updated_at"`
+}
+
+type CreateProjectTask struct {
+	Title            string                 `json:"title,omitempty"`
+	Description      string                 `json:"description,omitempty"`
+	StatusID         int64                  `json:"status_id,omitempty"`
+	PriorityID       int64                  `json:"priority_id,omitempty"`
+	TypeID           int64                  `json:"type_id,omitempty"`
+	AssigneeID       int64                  `json:"assignee_id,omitempty"`
+	ParentID         int64                  `json:"parent_id,omitempty"`
+	PlannedStartDate string                 `json:"planned_start_date,omitempty"`
+	PlannedEndDate   string                 `json:"planned_end_date,omitempty"`
+	PlannedEffort    string                 `json:"planned_effort,omitempty"`
+	CustomFields     map[string]interface{} `json:"custom_fields,omitempty"`
+}
+
+type RespProjects struct {
+	Projects []Project `json:"projects,omitempty"`
+}
+
+type RespProject struct {
+	Project Project `json:"project,omitempty"`
+}
+
+type RespProjectTasks struct {
+	Tasks []ProjectTask `json:"tasks,omitempty"`
+}
+
+type RespProjectTask struct {
+	Task ProjectTask `json:"task,omitempty"`
+}
+
+type ProjectSlice []Project
+
+func (s ProjectSlice) Len() int { return len(s) }
+
+func (s ProjectSlice) Less(i, j int) bool { return s[i].ID < s[j].ID }
+
+func (s ProjectSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s ProjectSlice) Print() {
+	for _, project := range s {
+		fmt.Println(project.Name)
+	}
+}
+
+type ProjectTaskSlice []ProjectTask
+
+func (s ProjectTaskSlice) Len() int { return len(s) }
+
+func (s ProjectTaskSlice) Less(i, j int) bool { return s[i].ID < s[j].ID }
+
+func (s ProjectTaskSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s ProjectTaskSlice) Print() {
+	for _, task := range s {
+		fmt.Println(task.Title)
+	}
+}
+
+func (manager projectManager) All() (ProjectSlice, error) {
+	resp := RespProjects{}
+	output := ProjectSlice{}
+	headers, err := manager.client.get(endpoints.projects.all, &resp)
+	if err != nil {
+		return ProjectSlice{}, err
+	}
+	output = append(output, resp.Projects...)
+
+	for {
+		nextLink := manager.client.getNextLink(headers)
+		if nextLink == "" {
+			break
+		}
+		nextResp := RespProjects{}
+		headers, err = manager.client.get(nextLink, &nextResp)
+		if err != nil {
+			return ProjectSlice{}, err
+		}
+		output = append(output, nextResp.Projects...)
+	}
+	return output, nil
+}
+
+func (manager projectManager) View(id int64) (Project, error) {
+	output := RespProject{}
+	_, err := manager.client.get(endpoints.projects.view(id), &output)
+	if err != nil {
+		return Project{}, err
+	}
+	return output.Project, nil
+}
+
+func (manager projectManager) Create(project CreateProject) (Project, error) {
+	output := RespProject{}
+	jsonb, err := json.Marshal(project)
+	if err != nil {
+		return Project{}, err
+	}
+	err = manager.client.postJSON(endpoints.projects.create, jsonb, &output, http.StatusCreated)
+	if err != nil {
+		return Project{}, err
+	}
+	return output.Project, nil
+}
+
+func (manager projectManager) Update(id int64, project CreateProject) (Project, error) {
+	output := RespProject{}
+	jsonb, err := json.Marshal(project)
+	if err != nil {
+		return Project{}, err
+	}
+	err = manager.client.put(endpoints.projects.update(id), jsonb, &output, http.StatusOK)
+	if err != nil {
+		return Project{}, err
+	}
+	return output.Project, nil
+}
+
+func (manager projectManager) Delete(id int64) error {
+	return manager.client.delete(endpoints.projects.delete(id), http.StatusNoContent)
+}
+
+func (manager projectManager) Tasks(id int64) (ProjectTaskSlice, error) {
+	resp := RespProjectTasks{}
+	output := ProjectTaskSlice{}
+	headers, err := manager.client.get(endpoints.projects.tasks(id), &resp)
+	if err != nil {
+		return ProjectTaskSlice{}, err
+	}
+	output = append(output, resp.Tasks...)
+
+	for {
+		nextLink := manager.client.getNextLink(headers)
+		if nextLink == "" {
+			break
+		}
+		nextResp := RespProjectTasks{}
+		headers, err = manager.client.get(nextLink, &nextResp)
+		if err != nil {
+			return ProjectTaskSlice{}, err
+		}
+		output = append(output, nextResp.Tasks...)
+	}
+	return output, nil
+}
+
+func (manager projectManager) ViewTask(id, taskID int64) (ProjectTask, error) {
+	output := RespProjectTask{}
+	_, err := manager.client.get(endpoints.projects.task(id, taskID), &output)
+	if err != nil {
+		return ProjectTask{}, err
+	}
+	return output.Task, nil
+}
+
+func (manager projectManager) CreateTask(id int64, task CreateProjectTask) (ProjectTask, error) {
+	output := RespProjectTask{}
+	jsonb, err := json.Marshal(task)
+	if err != nil {
+		return ProjectTask{}, err
+	}
+	err = manager.client.postJSON(endpoints.projects.tasks(id), jsonb, &output, http.StatusCreated)
+	if err != nil {
+		return ProjectTask{}, err
+	}
+	return output.Task, nil
+}
+
+func (manager projectManager) UpdateTask(id, taskID int64, task CreateProjectTask) (ProjectTask, error) {
+	output := RespProjectTask{}
+	jsonb, err := json.Marshal(task)
+	if err != nil {
+		return ProjectTask{}, err
+	}
+	err = manager.client.put(endpoints.projects.task(id, taskID), jsonb, &output, http.StatusOK)
+	if err != nil {
+		return ProjectTask{}, err
+	}
+	return output.Task, nil
+}
+
+func (manager projectManager) DeleteTask(id, taskID int64) error {
+	return manager.client.delete(endpoints.projects.task(id, taskID), http.StatusNoContent)
+}
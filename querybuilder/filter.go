@@ -0,0 +1,354 @@
+package querybuilder
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Filter is an expression in the Freshservice filter language, such as
+// "priority:>3 AND (status:2 OR status:3) AND tag:'vip'". Comparisons are
+// field:value for equality, field:>value for greater than or equal and
+// field:<value for less than or equal, where a value is a number, true,
+// false, null or a quoted string. Conditions combine with AND, OR and
+// parentheses.
+//
+// Besides rendering into a query, a filter can be matched locally against the
+// fields of a record.
+type Filter interface {
+	// String renders the expression.
+	String() string
+	// Match reports whether the fields satisfy the expression. A field that
+	// holds a slice matches an equality if any of its elements does.
+	Match(fields map[string]interface{}) bool
+}
+
+type condition struct {
+	field    string
+	operator string
+	value    interface{}
+}
+
+type junction struct {
+	operator string
+	filters  []Filter
+}
+
+// Equals matches records whose field is the value.
+func Equals(field string, value interface{}) Filter {
+	return condition{field, ":", value}
+}
+
+// AtLeast matches records whose field is greater than or equal to the value.
+func AtLeast(field string, value interface{}) Filter {
+	return condition{field, ":>", value}
+}
+
+// AtMost matches records whose field is less than or equal to the value.
+func AtMost(field string, value interface{}) Filter {
+	return condition{field, ":<", value}
+}
+
+// And matches records matching every filter.
+func And(filters ...Filter) Filter {
+	return junction{"AND", filters}
+}
+
+// Or matches records matching any filter.
+func Or(filters ...Filter) Filter {
+	return junction{"OR", filters}
+}
+
+// Filter sets the parameter to the quoted filter expression, as expected by
+// the filter endpoints.
+func (query Query) Filter(parameter string, filter Filter) {
+	query.Is(parameter, fmt.Sprintf("\"%s\"", filter.String()))
+}
+
+func (c condition) String() string {
+	return c.field + c.operator + formatValue(c.value)
+}
+
+func (c condition) Match(fields map[string]interface{}) bool {
+	actual, ok := fields[c.field]
+	value := reflect.ValueOf(actual)
+	if !ok || actual == nil || (value.Kind() == reflect.Ptr && value.IsNil()) {
+		return c.value == nil && c.operator == ":"
+	}
+	if value.Kind() == reflect.Slice && c.operator == ":" {
+		for i := 0; i < value.Len(); i++ {
+			if compare(value.Index(i).Interface(), c.value) == 0 {
+				return true
+			}
+		}
+		return false
+	}
+	order := compare(actual, c.value)
+	switch c.operator {
+	case ":>":
+		return order == 0 || order == 1
+	case ":<":
+		return order == 0 || order == -1
+	default:
+		return order == 0
+	}
+}
+
+func (j junction) String() string {
+	parts := make([]string, len(j.filters))
+	for i, filter := range j.filters {
+		parts[i] = filter.String()
+		if inner, ok := filter.(junction); ok && inner.operator != j.operator && len(inner.filters) > 1 {
+			parts[i] = "(" + parts[i] + ")"
+		}
+	}
+	return strings.Join(parts, " "+j.operator+" ")
+}
+
+func (j junction) Match(fields map[string]interface{}) bool {
+	for _, filter := range j.filters {
+		if filter.Match(fields) != (j.operator == "AND") {
+			return j.operator != "AND"
+		}
+	}
+	return j.operator == "AND"
+}
+
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "'" + strings.Replace(v, "'", "\\'", -1) + "'"
+	case time.Time:
+		return "'" + v.Format("2006-01-02") + "'"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// compare orders actual against the expected value, returning -1, 0 or 1, or
+// 2 when they cannot be compared. Numbers compare numerically, times against
+// dates, and strings case-insensitively.
+func compare(actual, expected interface{}) int {
+	if expected == nil {
+		if actual == nil {
+			return 0
+		}
+		return 2
+	}
+	if a, ok := toNumber(actual); ok {
+		if e, ok := toNumber(expected); ok {
+			return order(a < e, a > e)
+		}
+	}
+	if a, ok := actual.(bool); ok {
+		if e, ok := expected.(bool); ok && a == e {
+			return 0
+		}
+		return 2
+	}
+	if a, ok := toTime(actual); ok {
+		if e, ok := toTime(expected); ok {
+			return order(a.Before(e), a.After(e))
+		}
+		return 2
+	}
+	a, e := strings.ToLower(fmt.Sprint(actual)), strings.ToLower(fmt.Sprint(expected))
+	return order(a < e, a > e)
+}
+
+func order(less, greater bool) int {
+	if less {
+		return -1
+	}
+	if greater {
+		return 1
+	}
+	return 0
+}
+
+func toNumber(value interface{}) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.String:
+		number, err := strconv.ParseFloat(v.String(), 64)
+		return number, err == nil
+	}
+	return 0, false
+}
+
+func toTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case *time.Time:
+		if v != nil {
+			return *v, true
+		}
+	case string:
+		for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// ParseFilter parses a filter expression. AND binds tighter than OR.
+func ParseFilter(expression string) (Filter, error) {
+	p := &filterParser{input: expression}
+	filter, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpace(); p.pos < len(p.input) {
+		return nil, p.errorf("unexpected %q", p.input[p.pos:])
+	}
+	return filter, nil
+}
+
+type filterParser struct {
+	input string
+	pos   int
+}
+
+func (p *filterParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("filter %q at %d: %s", p.input, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *filterParser) skipSpace() {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// keyword consumes the word if it comes next, followed by a space or a
+// parenthesis.
+func (p *filterParser) keyword(word string) bool {
+	p.skipSpace()
+	end := p.pos + len(word)
+	if end > len(p.input) || !strings.EqualFold(p.input[p.pos:end], word) {
+		return false
+	}
+	if end < len(p.input) && !unicode.IsSpace(rune(p.input[end])) && p.input[end] != '(' {
+		return false
+	}
+	p.pos = end
+	return true
+}
+
+func (p *filterParser) parseOr() (Filter, error) {
+	return p.parseJunction("OR", p.parseAnd)
+}
+
+func (p *filterParser) parseAnd() (Filter, error) {
+	return p.parseJunction("AND", p.parseTerm)
+}
+
+func (p *filterParser) parseJunction(operator string, next func() (Filter, error)) (Filter, error) {
+	filter, err := next()
+	if err != nil {
+		return nil, err
+	}
+	filters := []Filter{filter}
+	for p.keyword(operator) {
+		filter, err := next()
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+	if len(filters) == 1 {
+		return filters[0], nil
+	}
+	return junction{operator, filters}, nil
+}
+
+func (p *filterParser) parseTerm() (Filter, error) {
+	p.skipSpace()
+	if p.pos < len(p.input) && p.input[p.pos] == '(' {
+		p.pos++
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.skipSpace(); p.pos >= len(p.input) || p.input[p.pos] != ')' {
+			return nil, p.errorf("missing )")
+		}
+		p.pos++
+		return filter, nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.input) && p.input[p.pos] != ':' && !unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+	field := p.input[start:p.pos]
+	if field == "" || p.pos >= len(p.input) || p.input[p.pos] != ':' {
+		return nil, p.errorf("expected field:value")
+	}
+	p.pos++
+	operator := ":"
+	if p.pos < len(p.input) && (p.input[p.pos] == '>' || p.input[p.pos] == '<') {
+		operator += string(p.input[p.pos])
+		p.pos++
+	}
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	return condition{field, operator, value}, nil
+}
+
+func (p *filterParser) parseValue() (interface{}, error) {
+	if p.pos < len(p.input) && p.input[p.pos] == '\'' {
+		p.pos++
+		var value strings.Builder
+		for p.pos < len(p.input) {
+			switch c := p.input[p.pos]; {
+			case c == '\\' && p.pos+1 < len(p.input):
+				value.WriteByte(p.input[p.pos+1])
+				p.pos += 2
+			case c == '\'':
+				p.pos++
+				return value.String(), nil
+			default:
+				value.WriteByte(c)
+				p.pos++
+			}
+		}
+		return nil, p.errorf("unterminated string")
+	}
+
+	start := p.pos
+	for p.pos < len(p.input) && !unicode.IsSpace(rune(p.input[p.pos])) && p.input[p.pos] != ')' {
+		p.pos++
+	}
+	word := p.input[start:p.pos]
+	switch strings.ToLower(word) {
+	case "":
+		return nil, p.errorf("missing value")
+	case "null":
+		return nil, nil
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	number, err := strconv.ParseFloat(word, 64)
+	if err != nil {
+		return nil, p.errorf("invalid value %q", word)
+	}
+	return number, nil
+}
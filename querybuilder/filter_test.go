@@ -0,0 +1,165 @@
+package querybuilder
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// status mimics the enum types of the library: an int with a name.
+type status int
+
+func (s status) String() string {
+	return map[status]string{2: "Open", 3: "Pending"}[s]
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       string
+		err        string
+	}{
+		{"status:2", "status:2", ""},
+		{"  status:2  ", "status:2", ""},
+		{"priority:>3 AND status:<4", "priority:>3 AND status:<4", ""},
+		{"status:2 OR status:3 AND tag:'vip'", "status:2 OR (status:3 AND tag:'vip')", ""},
+		{"(status:2 OR status:3) AND tag:'vip'", "(status:2 OR status:3) AND tag:'vip'", ""},
+		{"status:2 and tag:'vip'", "status:2 AND tag:'vip'", ""},
+		{"name:'O\\'Brien'", "name:'O\\'Brien'", ""},
+		{"group_id:null AND spam:false AND escalated:true", "group_id:null AND spam:false AND escalated:true", ""},
+		{"sub:'a b'", "sub:'a b'", ""},
+		{"status:", "", "missing value"},
+		{"status", "", "expected field:value"},
+		{":2", "", "expected field:value"},
+		{"status:2 AND", "", "expected field:value"},
+		{"name:'open", "", "unterminated string"},
+		{"(status:2", "", "missing )"},
+		{"status:2)", "", "unexpected"},
+		{"status:two", "", "invalid value"},
+	}
+	for _, test := range tests {
+		filter, err := ParseFilter(test.expression)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("ParseFilter(%q) error = %v, want %q", test.expression, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseFilter(%q) error = %v", test.expression, err)
+			continue
+		}
+		if got := filter.String(); got != test.want {
+			t.Errorf("ParseFilter(%q) = %q, want %q", test.expression, got, test.want)
+		}
+	}
+}
+
+func TestFilterBuilders(t *testing.T) {
+	filter := And(Equals("status", 2), Or(AtLeast("priority", 3), Equals("tag", "vip")), AtMost("due_by", time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)))
+	want := "status:2 AND (priority:>3 OR tag:'vip') AND due_by:<'2020-05-01'"
+	if got := filter.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	query := BuildQuery()
+	query.Filter("query", Equals("name", "HQ"))
+	if got, want := query.URLSafe(), "query=%22name%3A%27HQ%27%22"; got != want {
+		t.Errorf("URLSafe() = %q, want %q", got, want)
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	created := time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+	var unset *time.Time
+	fields := map[string]interface{}{
+		"status":     status(2),
+		"priority":   int64(3),
+		"urgency":    "2",
+		"subject":    "Printer on fire",
+		"tag":        []string{"VIP", "printer"},
+		"group_id":   nil,
+		"due_by":     unset,
+		"created_at": &created,
+		"spam":       false,
+	}
+	tests := []struct {
+		expression string
+		want       bool
+	}{
+		{"status:2", true},
+		{"status:3", false},
+		{"status:'open'", true},
+		{"status:'Pending'", false},
+		{"urgency:2", true},
+		{"subject:'printer on fire'", true},
+		{"subject:'printer'", false},
+		{"tag:'vip'", true},
+		{"tag:'printer'", true},
+		{"tag:'network'", false},
+		{"group_id:null", true},
+		{"due_by:null", true},
+		{"missing:null", true},
+		{"priority:null", false},
+		{"group_id:1", false},
+		{"group_id:>1", false},
+		{"priority:>3", true},
+		{"priority:>4", false},
+		{"priority:<3", true},
+		{"priority:<2", false},
+		{"created_at:>'2020-05-01'", true},
+		{"created_at:<'2020-05-01'", false},
+		{"created_at:<'2020-05-01T12:00:00Z'", true},
+		{"created_at:>'2020-05-02'", false},
+		{"created_at:'soon'", false},
+		{"spam:false", true},
+		{"spam:true", false},
+		{"spam:0", false},
+		{"status:2 AND tag:'vip'", true},
+		{"status:3 AND tag:'vip'", false},
+		{"status:3 OR tag:'vip'", true},
+		{"status:3 OR tag:'network'", false},
+		{"status:3 OR priority:>3 AND tag:'vip'", true},
+		{"(status:3 OR priority:>3) AND tag:'network'", false},
+	}
+	for _, test := range tests {
+		filter, err := ParseFilter(test.expression)
+		if err != nil {
+			t.Errorf("ParseFilter(%q) error = %v", test.expression, err)
+			continue
+		}
+		if got := filter.Match(fields); got != test.want {
+			t.Errorf("%q.Match() = %v, want %v", test.expression, got, test.want)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	date := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		actual, expected interface{}
+		want             int
+	}{
+		{1, 2.0, -1},
+		{int64(2), 2.0, 0},
+		{uint8(3), 2.0, 1},
+		{"10", 9.0, 1},
+		{status(2), 2.0, 0},
+		{status(2), "OPEN", 0},
+		{true, true, 0},
+		{true, false, 2},
+		{true, 1.0, 2},
+		{date, "2020-05-01", 0},
+		{&date, "2020-04-30", 1},
+		{date, "not a date", 2},
+		{"b", "A", 1},
+		{"a", "B", -1},
+		{nil, nil, 0},
+		{"x", nil, 2},
+	}
+	for _, test := range tests {
+		if got := compare(test.actual, test.expected); got != test.want {
+			t.Errorf("compare(%#v, %#v) = %d, want %d", test.actual, test.expected, got, test.want)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package querybuilder
 import (
 	"net/url"
 	"strings"
+	"time"
 )
 
 type Query struct {
@@ -25,3 +26,13 @@ func (query Query) Parameter(parameter string) {
 func (query Query) Is(parameter string, value ...string) {
 	query.query[parameter] = value
 }
+
+// IsTime sets the parameter to the time in RFC 3339 format.
+func (query Query) IsTime(parameter string, t time.Time) {
+	query.query[parameter] = []string{t.Format(time.RFC3339)}
+}
+
+// UpdatedSince restricts results to those updated at or after t.
+func (query Query) UpdatedSince(t time.Time) {
+	query.IsTime("updated_since", t)
+}
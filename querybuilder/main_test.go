@@ -0,0 +1,43 @@
+package querybuilder
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestUpdatedSinceEscaping(t *testing.T) {
+	tests := []struct {
+		name string
+		time time.Time
+		want string
+	}{
+		{"utc", time.Date(2020, 5, 1, 9, 30, 0, 0, time.UTC), "updated_since=2020-05-01T09%3A30%3A00Z"},
+		{"positive offset", time.Date(2020, 5, 1, 9, 30, 0, 0, time.FixedZone("CEST", 2*3600)), "updated_since=2020-05-01T09%3A30%3A00%2B02%3A00"},
+		{"negative offset", time.Date(2020, 5, 1, 9, 30, 0, 0, time.FixedZone("EDT", -4*3600)), "updated_since=2020-05-01T09%3A30%3A00-04%3A00"},
+	}
+	for _, test := range tests {
+		query := BuildQuery()
+		query.UpdatedSince(test.time)
+		got := query.URLSafe()
+		if got != test.want {
+			t.Errorf("%s: URLSafe() = %s, want %s", test.name, got, test.want)
+		}
+		values, err := url.ParseQuery(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed, err := time.Parse(time.RFC3339, values.Get("updated_since"))
+		if err != nil || !parsed.Equal(test.time) {
+			t.Errorf("%s: decoded %v, %v, want %v", test.name, parsed, err, test.time)
+		}
+	}
+}
+
+func TestURLSafeSpaces(t *testing.T) {
+	query := BuildQuery()
+	query.Is("query", `"name:'Jane Doe'"`)
+	if got, want := query.URLSafe(), "query=%22name%3A%27Jane%20Doe%27%22"; got != want {
+		t.Errorf("URLSafe() = %s, want %s", got, want)
+	}
+}
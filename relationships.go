@@ -0,0 +1,196 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type RelationshipManager interface {
+	All() (RelationshipSlice, error)
+	View(int64) (Relationship, error)
+	ForAsset(int64) (RelationshipSlice, error)
+	Create(...CreateRelationship) (string, error)
+	Delete(...int64) error
+	Types() (RelationshipTypeSlice, error)
+}
+
+type relationshipManager struct {
+	client *ApiClient
+}
+
+func newRelationshipManager(client *ApiClient) relationshipManager {
+	return relationshipManager{
+		client,
+	}
+}
+
+// Relationship links a primary item to a secondary item. Assets are referenced
+// by their display ID.
+type Relationship struct {
+	ID                 int64      `bson:"id" json:"id"`
+	RelationshipTypeID int64      `bson:"relationship_type_id" json:"relationship_type_id"`
+	PrimaryID          int64      `bson:"primary_id" json:"primary_id"`
+	PrimaryType        string     `bson:"primary_type" json:"primary_type"`
+	SecondaryID        int64      `bson:"secondary_id" json:"secondary_id"`
+	SecondaryType      string     `bson:"secondary_type" json:"secondary_type"`
+	CreatedAt          *time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt          *time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+const RelationshipItemAsset = "asset"
+
+type CreateRelationship struct {
+	RelationshipTypeID int64  `json:"relationship_type_id"`
+	PrimaryID          int64  `json:"primary_id"`
+	PrimaryType        string `json:"primary_type"`
+	SecondaryID        int64  `json:"secondary_id"`
+	SecondaryType      string `json:"secondary_type"`
+}
+
+// RelationshipType names both directions of a relationship, e.g.
+// "Depends On" downstream and "Used By" upstream.
+type RelationshipType struct {
+	ID                 int64      `bson:"id" json:"id"`
+	Description        string     `bson:"description" json:"description"`
+	DownstreamRelation string     `bson:"downstream_relation" json:"downstream_relation"`
+	UpstreamRelation   string     `bson:"upstream_relation" json:"upstream_relation"`
+	CreatedAt          *time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt          *time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+type RespRelationships struct {
+	Relationships []Relationship `json:"relationships,omitempty"`
+}
+
+type RespRelationship struct {
+	Relationship Relationship `json:"relationship,omitempty"`
+}
+
+type RespRelationshipTypes struct {
+	RelationshipTypes []RelationshipType `json:"relationship_types,omitempty"`
+}
+
+type RelationshipSlice []Relationship
+
+func (s RelationshipSlice) Len() int { return len(s) }
+
+func (s RelationshipSlice) Less(i, j int) bool { return s[i].ID < s[j].ID }
+
+func (s RelationshipSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s RelationshipSlice) Print() {
+	for _, relationship := range s {
+		fmt.Printf("%s %d -> %s %d\n", relationship.PrimaryType, relationship.PrimaryID, relationship.SecondaryType, relationship.SecondaryID)
+	}
+}
+
+type RelationshipTypeSlice []RelationshipType
+
+func (s RelationshipTypeSlice) Len() int { return len(s) }
+
+func (s RelationshipTypeSlice) Less(i, j int) bool { return s[i].ID < s[j].ID }
+
+func (s RelationshipTypeSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s RelationshipTypeSlice) Print() {
+	for _, relationshipType := range s {
+		fmt.Println(relationshipType.DownstreamRelation, "/", relationshipType.UpstreamRelation)
+	}
+}
+
+func (manager relationshipManager) All() (RelationshipSlice, error) {
+	return manager.collect(endpoints.relationships.all)
+}
+
+func (manager relationshipManager) View(id int64) (Relationship, error) {
+	output := RespRelationship{}
+	_, err := manager.client.get(endpoints.relationships.view(id), &output)
+	if err != nil {
+		return Relationship{}, err
+	}
+	return output.Relationship, nil
+}
+
+// ForAsset returns the relationships in which the asset takes part.
+func (manager relationshipManager) ForAsset(displayID int64) (RelationshipSlice, error) {
+	return manager.collect(endpoints.relationships.asset(displayID))
+}
+
+// Create adds the relationships in bulk. Freshservice processes them
+// asynchronously and the returned job ID can be used to follow up.
+func (manager relationshipManager) Create(relationships ...CreateRelationship) (string, error) {
+	output := struct {
+		JobID string `json:"job_id"`
+	}{}
+	jsonb, err := json.Marshal(struct {
+		Relationships []CreateRelationship `json:"relationships"`
+	}{relationships})
+	if err != nil {
+		return "", err
+	}
+	err = manager.client.postJSON(endpoints.relationships.bulkCreate, jsonb, &output, http.StatusAccepted)
+	if err != nil {
+		return "", err
+	}
+	return output.JobID, nil
+}
+
+func (manager relationshipManager) Delete(ids ...int64) error {
+	values := make([]string, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, strconv.FormatInt(id, 10))
+	}
+	return manager.client.delete(endpoints.relationships.delete(strings.Join(values, ",")), http.StatusNoContent)
+}
+
+func (manager relationshipManager) Types() (RelationshipTypeSlice, error) {
+	resp := RespRelationshipTypes{}
+	output := RelationshipTypeSlice{}
+	headers, err := manager.client.get(endpoints.relationships.types, &resp)
+	if err != nil {
+		return RelationshipTypeSlice{}, err
+	}
+	output = append(output, resp.RelationshipTypes...)
+
+	for {
+		nextLink := manager.client.getNextLink(headers)
+		if nextLink == "" {
+			break
+		}
+		nextResp := RespRelationshipTypes{}
+		headers, err = manager.client.get(nextLink, &nextResp)
+		if err != nil {
+			return RelationshipTypeSlice{}, err
+		}
+		output = append(output, nextResp.RelationshipTypes...)
+	}
+	return output, nil
+}
+
+func (manager relationshipManager) collect(path string) (RelationshipSlice, error) {
+	resp := RespRelationships{}
+	output := RelationshipSlice{}
+	headers, err := manager.client.get(path, &resp)
+	if err != nil {
+		return RelationshipSlice{}, err
+	}
+	output = append(output, resp.Relationships...)
+
+	for {
+		nextLink := manager.client.getNextLink(headers)
+		if nextLink == "" {
+			break
+		}
+		nextResp := RespRelationships{}
+		headers, err = manager.client.get(nextLink, &nextResp)
+		if err != nil {
+			return RelationshipSlice{}, err
+		}
+		output = append(output, nextResp.Relationships...)
+	}
+	return output, nil
+}
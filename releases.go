@@ -0,0 +1,292 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/nextlinktechnology/mgm/v3"
+)
+
+type ReleaseManager interface {
+	All() (ReleaseSlice, error)
+	View(int64) (Release, error)
+	Create(CreateRelease) (Release, error)
+	Update(int64, CreateRelease) (Release, error)
+	Delete(int64) error
+	Notes(int64) (ConversationSlice, error)
+	AddNote(int64, CreateConversation) (Conversation, error)
+	Tasks(int64) (TaskSlice, error)
+	CreateTask(int64, CreateTask) (Task, error)
+	UpdateTask(int64, int64, CreateTask) (Task, error)
+	DeleteTask(int64, int64) error
+	TimeEntries(int64) (TimeEntrySlice, error)
+	CreateTimeEntry(int64, CreateTimeEntry) (TimeEntry, error)
+	LinkChanges(int64, ...int64) (Release, error)
+	Complete(int64) (Release, error)
+}
+
+type releaseManager struct {
+	client *ApiClient
+}
+
+func newReleaseManager(client *ApiClient) releaseManager {
+	return releaseManager{
+		client,
+	}
+}
+
+type Release struct {
+	mgm.DefaultModel  `bson:",inline" json:"-"`
+	ID                int64                  `bson:"id" json:"id"`
+	AgentID           int64                  `bson:"agent_id" json:"agent_id"`
+	GroupID           int64                  `bson:"group_id" json:"group_id"`
+	DepartmentID      int64                  `bson:"department_id" json:"department_id"`
+	Subject           string                 `bson:"subject" json:"subject"`
+	Description       string                 `bson:"description" json:"description"`
+	DescriptionText   string                 `bson:"description_text" json:"description_text"`
+	Priority          int                    `bson:"priority" json:"priority"`
+	Status            int                    `bson:"status" json:"status"`
+	ReleaseType       int                    `bson:"release_type" json:"release_type"`
+	PlannedStartDate  *time.Time             `bson:"planned_start_date" json:"planned_start_date"`
+	PlannedEndDate    *time.Time             `bson:"planned_end_date" json:"planned_end_date"`
+	WorkStartDate     *time.Time             `bson:"work_start_date" json:"work_start_date"`
+	WorkEndDate       *time.Time             `bson:"work_end_date" json:"work_end_date"`
+	Category          string                 `bson:"category" json:"category"`
+	SubCategory       string                 `bson:"sub_category" json:"sub_category"`
+	ItemCategory      string                 `bson:"item_category" json:"item_category"`
+	AssociatedAssets  []int64                `bson:"associated_assets" json:"associated_assets"`
+	AssociatedChanges []int64                `bson:"associated_changes" json:"associated_changes"`
+	PlanningFields    ReleasePlanningFields  `bson:"planning_fields" json:"planning_fields"`
+	Attachments       []interface{}          `bson:"attachments" json:"attachments"`
+	CustomFields      map[string]interface{} `bson:"custom_fields" json:"custom_fields"`
+	CreatedAt         *time.Time             `bson:"created_at" json:"created_at"`
+	UpdatedAt         *time.Time             `bson:"updated_at" json:"updated_at"`
+}
+
+// ReleasePlanningFields holds the planning section of a release.
+type ReleasePlanningFields struct {
+	BuildPlan *PlanningField `bson:"build_plan" json:"build_plan,omitempty"`
+	TestPlan  *PlanningField `bson:"test_plan" json:"test_plan,omitempty"`
+}
+
+type CreateRelease struct {
+	AgentID           int64                  `json:"agent_id,omitempty"`
+	GroupID           int64                  `json:"group_id,omitempty"`
+	DepartmentID      int64                  `json:"department_id,omitempty"`
+	Subject           string                 `json:"subject,omitempty"`
+	Description       string                 `json:"description,omitempty"`
+	Priority          int                    `json:"priority,omitempty"`
+	Status            int                    `json:"status,omitempty"`
+	ReleaseType       int                    `json:"release_type,omitempty"`
+	PlannedStartDate  *time.Time             `json:"planned_start_date,omitempty"`
+	PlannedEndDate    *time.Time             `json:"planned_end_date,omitempty"`
+	WorkStartDate     *time.Time             `json:"work_start_date,omitempty"`
+	WorkEndDate       *time.Time             `json:"work_end_date,omitempty"`
+	Category          string                 `json:"category,omitempty"`
+	SubCategory       string                 `json:"sub_category,omitempty"`
+	ItemCategory      string                 `json:"item_category,omitempty"`
+	AssociatedAssets  []int64                `json:"associated_assets,omitempty"`
+	AssociatedChanges []int64                `json:"associated_changes,omitempty"`
+	PlanningFields    *ReleasePlanningFields `json:"planning_fields,omitempty"`
+	Attachments       []interface{}          `json:"attachments,omitempty"`
+	CustomFields      map[string]interface{} `json:"custom_fields,omitempty"`
+}
+
+type ReleaseStatus int
+type ReleaseType int
+
+const (
+	ReleaseStatusOpen ReleaseStatus = 1 + iota
+	ReleaseStatusOnHold
+	ReleaseStatusInProgress
+	ReleaseStatusIncomplete
+	ReleaseStatusCompleted
+)
+
+const (
+	ReleaseTypeMinor ReleaseType = 1 + iota
+	ReleaseTypeStandard
+	ReleaseTypeMajor
+	ReleaseTypeEmergency
+)
+
+func (s ReleaseStatus) Value() int {
+	return int(s)
+}
+
+func (t ReleaseType) Value() int {
+	return int(t)
+}
+
+type RespReleases struct {
+	Releases []Release `json:"releases,omitempty"`
+}
+
+type RespRelease struct {
+	Release Release `json:"release,omitempty"`
+}
+
+func (r Release) Print() {
+	jsonb, _ := json.MarshalIndent(r, "", "    ")
+	fmt.Println(string(jsonb))
+}
+
+type ReleaseSlice []Release
+
+func (s ReleaseSlice) Len() int { return len(s) }
+
+func (s ReleaseSlice) Less(i, j int) bool { return s[i].ID < s[j].ID }
+
+func (s ReleaseSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s ReleaseSlice) Print() {
+	for _, release := range s {
+		fmt.Println(release.Subject)
+	}
+}
+
+func (manager releaseManager) All() (ReleaseSlice, error) {
+	resp := RespReleases{}
+	output := ReleaseSlice{}
+	headers, err := manager.client.get(endpoints.releases.all, &resp)
+	if err != nil {
+		return ReleaseSlice{}, err
+	}
+	output = append(output, resp.Releases...)
+
+	for {
+		nextLink := manager.client.getNextLink(headers)
+		if nextLink == "" {
+			break
+		}
+		nextResp := RespReleases{}
+		headers, err = manager.client.get(nextLink, &nextResp)
+		if err != nil {
+			return ReleaseSlice{}, err
+		}
+		output = append(output, nextResp.Releases...)
+	}
+	return output, nil
+}
+
+func (manager releaseManager) View(id int64) (Release, error) {
+	output := RespRelease{}
+	_, err := manager.client.get(endpoints.releases.view(id), &output)
+	if err != nil {
+		return Release{}, err
+	}
+	return output.Release, nil
+}
+
+func (manager releaseManager) Create(release CreateRelease) (Release, error) {
+	output := RespRelease{}
+	jsonb, err := json.Marshal(release)
+	if err != nil {
+		return Release{}, err
+	}
+	err = manager.client.postJSON(endpoints.releases.create, jsonb, &output, http.StatusCreated)
+	if err != nil {
+		return Release{}, err
+	}
+	return output.Release, nil
+}
+
+func (manager releaseManager) Update(id int64, release CreateRelease) (Release, error) {
+	output := RespRelease{}
+	jsonb, err := json.Marshal(release)
+	if err != nil {
+		return Release{}, err
+	}
+	err = manager.client.put(endpoints.releases.update(id), jsonb, &output, http.StatusOK)
+	if err != nil {
+		return Release{}, err
+	}
+	return output.Release, nil
+}
+
+func (manager releaseManager) Delete(id int64) error {
+	return manager.client.delete(endpoints.releases.delete(id), http.StatusNoContent)
+}
+
+func (manager releaseManager) Notes(id int64) (ConversationSlice, error) {
+	return manager.client.getNotes(endpoints.releases.notes(id))
+}
+
+func (manager releaseManager) AddNote(id int64, note CreateConversation) (Conversation, error) {
+	return manager.client.postNote(endpoints.releases.notes(id), note)
+}
+
+func (manager releaseManager) Tasks(id int64) (TaskSlice, error) {
+	return manager.client.getTasks(endpoints.releases.tasks(id))
+}
+
+func (manager releaseManager) CreateTask(id int64, task CreateTask) (Task, error) {
+	return manager.client.postTask(endpoints.releases.tasks(id), task)
+}
+
+func (manager releaseManager) UpdateTask(id, taskID int64, task CreateTask) (Task, error) {
+	return manager.client.putTask(endpoints.releases.task(id, taskID), task)
+}
+
+func (manager releaseManager) DeleteTask(id, taskID int64) error {
+	return manager.client.delete(endpoints.releases.task(id, taskID), http.StatusNoContent)
+}
+
+func (manager releaseManager) TimeEntries(id int64) (TimeEntrySlice, error) {
+	return manager.client.getTimeEntries(endpoints.releases.timeEntries(id))
+}
+
+func (manager releaseManager) CreateTimeEntry(id int64, entry CreateTimeEntry) (TimeEntry, error) {
+	return manager.client.postTimeEntry(endpoints.releases.timeEntries(id), entry)
+}
+
+// LinkChanges adds the changes to those already associated with the release.
+// The API replaces the whole list, so the release is read first and nothing is
+// written unless that read succeeded.
+func (manager releaseManager) LinkChanges(id int64, changeIDs ...int64) (Release, error) {
+	release, err := manager.current(id)
+	if err != nil {
+		return Release{}, err
+	}
+	add, _ := diffMembers(release.AssociatedChanges, changeIDs)
+	if len(add) == 0 {
+		return release, nil
+	}
+	changes := make([]int64, len(release.AssociatedChanges), len(release.AssociatedChanges)+len(add))
+	copy(changes, release.AssociatedChanges)
+	changes = append(changes, add...)
+	return manager.Update(id, CreateRelease{AssociatedChanges: changes})
+}
+
+// current reads the release before it is updated from its current values,
+// making sure the response really is the release.
+func (manager releaseManager) current(id int64) (Release, error) {
+	release, err := manager.View(id)
+	if err != nil {
+		return Release{}, err
+	}
+	if release.ID != id {
+		return Release{}, fmt.Errorf("reading release %d: response holds no release", id)
+	}
+	return release, nil
+}
+
+// Complete marks the release as completed, recording now as the work end date
+// if none was set.
+func (manager releaseManager) Complete(id int64) (Release, error) {
+	release, err := manager.current(id)
+	if err != nil {
+		return Release{}, err
+	}
+	update := CreateRelease{Status: ReleaseStatusCompleted.Value()}
+	if release.WorkEndDate == nil {
+		now := time.Now()
+		update.WorkEndDate = &now
+		if release.WorkStartDate == nil {
+			update.WorkStartDate = &now
+		}
+	}
+	return manager.Update(id, update)
+}
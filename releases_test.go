@@ -0,0 +1,82 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+// fakeReleaseAPI serves a release's view and update endpoints, recording the
+// update payloads.
+type fakeReleaseAPI struct {
+	view    http.HandlerFunc
+	updates []string
+}
+
+func (api *fakeReleaseAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		api.view(w, r)
+		return
+	}
+	body, _ := ioutil.ReadAll(r.Body)
+	api.updates = append(api.updates, string(body))
+	w.Write([]byte(`{"release": {"id": 5}}`))
+}
+
+func TestReleaseLinkChanges(t *testing.T) {
+	release := respond(http.StatusOK, `{"release": {"id": 5, "associated_changes": [10, 11]}}`)
+	tests := []struct {
+		name        string
+		view        http.HandlerFunc
+		changes     []int64
+		wantUpdates []string
+		wantErr     bool
+	}{
+		{"link", release, []int64{11, 12, 12}, []string{`{"associated_changes":[10,11,12]}`}, false},
+		{"already linked", release, []int64{10}, nil, false},
+		{"failed read", respond(http.StatusInternalServerError, ``), []int64{12}, nil, true},
+		{"empty read", respond(http.StatusOK, `{}`), []int64{12}, nil, true},
+	}
+	for _, test := range tests {
+		api := &fakeReleaseAPI{view: test.view}
+		client := testClient(api.ServeHTTP)
+		_, err := client.Releases.LinkChanges(5, test.changes...)
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: error = %v", test.name, err)
+		}
+		if len(api.updates) != len(test.wantUpdates) {
+			t.Errorf("%s: updates %v, want %v", test.name, api.updates, test.wantUpdates)
+			continue
+		}
+		for i := range api.updates {
+			if api.updates[i] != test.wantUpdates[i] {
+				t.Errorf("%s: update %s, want %s", test.name, api.updates[i], test.wantUpdates[i])
+			}
+		}
+	}
+}
+
+func TestReleaseCompleteAfterFailedRead(t *testing.T) {
+	api := &fakeReleaseAPI{view: respond(http.StatusOK, `{"release": {}}`)}
+	if _, err := testClient(api.ServeHTTP).Releases.Complete(5); err == nil {
+		t.Error("Complete() accepted an empty release")
+	}
+	if len(api.updates) != 0 {
+		t.Errorf("updates after a failed read: %v", api.updates)
+	}
+}
+
+func TestReleaseComplete(t *testing.T) {
+	api := &fakeReleaseAPI{view: respond(http.StatusOK, `{"release": {"id": 5, "work_start_date": "2020-05-01T08:00:00Z"}}`)}
+	if _, err := testClient(api.ServeHTTP).Releases.Complete(5); err != nil {
+		t.Fatal(err)
+	}
+	update := map[string]interface{}{}
+	if len(api.updates) != 1 || json.Unmarshal([]byte(api.updates[0]), &update) != nil {
+		t.Fatalf("updates = %v", api.updates)
+	}
+	if update["status"] != float64(ReleaseStatusCompleted.Value()) || update["work_end_date"] == nil || update["work_start_date"] != nil {
+		t.Errorf("update = %v", update)
+	}
+}
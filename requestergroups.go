@@ -0,0 +1,212 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nextlinktechnology/mgm/v3"
+)
+
+type RequesterGroupManager interface {
+	All() (RequesterGroupSlice, error)
+	View(int64) (RequesterGroup, error)
+	FindByName(string) (RequesterGroup, error)
+	Create(CreateRequesterGroup) (RequesterGroup, error)
+	Update(int64, CreateRequesterGroup) (RequesterGroup, error)
+	Delete(int64) error
+	Members(int64) (RequesterSlice, error)
+	AddMember(int64, int64) error
+	RemoveMember(int64, int64) error
+	SyncMembers(int64, []int64) error
+}
+
+type requesterGroupManager struct {
+	client *ApiClient
+}
+
+func newRequesterGroupManager(client *ApiClient) requesterGroupManager {
+	return requesterGroupManager{
+		client,
+	}
+}
+
+type RequesterGroup struct {
+	mgm.DefaultModel `bson:",inline" json:"-"`
+	ID               int64      `bson:"id" json:"id"`
+	Name             string     `bson:"name" json:"name"`
+	Description      string     `bson:"description" json:"description"`
+	Type             string     `bson:"type" json:"type"`
+	CreatedAt        *time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt        *time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// Membership of rule based requester groups is managed by Freshservice and
+// cannot be changed through the API.
+const (
+	RequesterGroupTypeManual    = "manual"
+	RequesterGroupTypeRuleBased = "rule_based"
+)
+
+type CreateRequesterGroup struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type RespRequesterGroups struct {
+	RequesterGroups []RequesterGroup `json:"requester_groups,omitempty"`
+}
+
+type RespRequesterGroup struct {
+	RequesterGroup RequesterGroup `json:"requester_group,omitempty"`
+}
+
+type RequesterGroupSlice []RequesterGroup
+
+func (s RequesterGroupSlice) Len() int { return len(s) }
+
+func (s RequesterGroupSlice) Less(i, j int) bool { return s[i].ID < s[j].ID }
+
+func (s RequesterGroupSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s RequesterGroupSlice) Print() {
+	for _, group := range s {
+		fmt.Println(group.Name)
+	}
+}
+
+func (manager requesterGroupManager) All() (RequesterGroupSlice, error) {
+	resp := RespRequesterGroups{}
+	output := RequesterGroupSlice{}
+	headers, err := manager.client.get(endpoints.requesterGroups.all, &resp)
+	if err != nil {
+		return RequesterGroupSlice{}, err
+	}
+	output = append(output, resp.RequesterGroups...)
+
+	for {
+		nextLink := manager.client.getNextLink(headers)
+		if nextLink == "" {
+			break
+		}
+		nextResp := RespRequesterGroups{}
+		headers, err = manager.client.get(nextLink, &nextResp)
+		if err != nil {
+			return RequesterGroupSlice{}, err
+		}
+		output = append(output, nextResp.RequesterGroups...)
+	}
+	return output, nil
+}
+
+func (manager requesterGroupManager) View(id int64) (RequesterGroup, error) {
+	output := RespRequesterGroup{}
+	_, err := manager.client.get(endpoints.requesterGroups.view(id), &output)
+	if err != nil {
+		return RequesterGroup{}, err
+	}
+	return output.RequesterGroup, nil
+}
+
+// FindByName returns the requester group whose name matches, ignoring case, or ErrNotFound.
+func (manager requesterGroupManager) FindByName(name string) (RequesterGroup, error) {
+	groups, err := manager.All()
+	if err != nil {
+		return RequesterGroup{}, err
+	}
+	for _, group := range groups {
+		if strings.EqualFold(group.Name, name) {
+			return group, nil
+		}
+	}
+	return RequesterGroup{}, ErrNotFound
+}
+
+func (manager requesterGroupManager) Create(group CreateRequesterGroup) (RequesterGroup, error) {
+	output := RespRequesterGroup{}
+	jsonb, err := json.Marshal(group)
+	if err != nil {
+		return RequesterGroup{}, err
+	}
+	err = manager.client.postJSON(endpoints.requesterGroups.create, jsonb, &output, http.StatusCreated)
+	if err != nil {
+		return RequesterGroup{}, err
+	}
+	return output.RequesterGroup, nil
+}
+
+func (manager requesterGroupManager) Update(id int64, group CreateRequesterGroup) (RequesterGroup, error) {
+	output := RespRequesterGroup{}
+	jsonb, err := json.Marshal(group)
+	if err != nil {
+		return RequesterGroup{}, err
+	}
+	err = manager.client.put(endpoints.requesterGroups.update(id), jsonb, &output, http.StatusOK)
+	if err != nil {
+		return RequesterGroup{}, err
+	}
+	return output.RequesterGroup, nil
+}
+
+func (manager requesterGroupManager) Delete(id int64) error {
+	return manager.client.delete(endpoints.requesterGroups.delete(id), http.StatusNoContent)
+}
+
+func (manager requesterGroupManager) Members(id int64) (RequesterSlice, error) {
+	resp := RespRequesters{}
+	output := RequesterSlice{}
+	headers, err := manager.client.get(endpoints.requesterGroups.members(id), &resp)
+	if err != nil {
+		return RequesterSlice{}, err
+	}
+	output = append(output, resp.Requesters...)
+
+	for {
+		nextLink := manager.client.getNextLink(headers)
+		if nextLink == "" {
+			break
+		}
+		nextResp := RespRequesters{}
+		headers, err = manager.client.get(nextLink, &nextResp)
+		if err != nil {
+			return RequesterSlice{}, err
+		}
+		output = append(output, nextResp.Requesters...)
+	}
+	return output, nil
+}
+
+func (manager requesterGroupManager) AddMember(id int64, requesterID int64) error {
+	return manager.client.postJSON(endpoints.requesterGroups.member(id, requesterID), nil, nil, http.StatusCreated)
+}
+
+func (manager requesterGroupManager) RemoveMember(id int64, requesterID int64) error {
+	return manager.client.delete(endpoints.requesterGroups.member(id, requesterID), http.StatusNoContent)
+}
+
+// SyncMembers adds and removes members so the group contains exactly the given
+// requesters, e.g. to mirror a group from an identity provider.
+func (manager requesterGroupManager) SyncMembers(id int64, requesterIDs []int64) error {
+	members, err := manager.Members(id)
+	if err != nil {
+		return err
+	}
+	current := make([]int64, 0, len(members))
+	for _, member := range members {
+		current = append(current, member.ID)
+	}
+	add, remove := diffMembers(current, requesterIDs)
+	for _, requesterID := range add {
+		if err := manager.AddMember(id, requesterID); err != nil {
+			return err
+		}
+	}
+	for _, requesterID := range remove {
+		if err := manager.RemoveMember(id, requesterID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -14,7 +14,8 @@ type RequesterManager interface {
 	All() (RequesterSlice, error)
 	Create(*Requester) (*Requester, error)
 	Search(querybuilder.Query) (RequesterResults, error)
-	Update(int64, *Requester) (*Requester, error)
+	Update(int64, UpdateRequester) (*Requester, error)
+	UpdatedSince(time.Time) (RequesterSlice, error)
 }
 
 type requesterManager struct {
@@ -59,6 +60,34 @@ type Requester struct {
 	UpdatedAt                                 *time.Time             `bson:"updated_at" json:"updated_at,omitempty"`
 }
 
+// UpdateRequester holds the fields to change on a requester. Only non-nil
+// fields are sent, so false, zero and empty values can be set explicitly, and
+// the manager and location cleared with Null.
+type UpdateRequester struct {
+	FirstName                                 *string                `json:"first_name,omitempty"`
+	LastName                                  *string                `json:"last_name,omitempty"`
+	JobTitle                                  *string                `json:"job_title,omitempty"`
+	PrimaryEmail                              *string                `json:"primary_email,omitempty"`
+	SecondaryEmails                           *[]string              `json:"secondary_emails,omitempty"`
+	WorkPhoneNumber                           *string                `json:"work_phone_number,omitempty"`
+	MobilePhoneNumber                         *string                `json:"mobile_phone_number,omitempty"`
+	DepartmentIDs                             *[]int64               `json:"department_ids,omitempty"`
+	CanSeeAllTicketsFromAssociatedDepartments *bool                  `json:"can_see_all_tickets_from_associated_departments,omitempty"`
+	ReportingManagerID                        *NullInt64             `json:"reporting_manager_id,omitempty"`
+	Address                                   *string                `json:"address,omitempty"`
+	TimeZone                                  *string                `json:"time_zone,omitempty"`
+	TimeFormat                                *string                `json:"time_format,omitempty"`
+	Language                                  *string                `json:"language,omitempty"`
+	LocationID                                *NullInt64             `json:"location_id,omitempty"`
+	BackgroundInformation                     *string                `json:"background_information,omitempty"`
+	CustomFields                              map[string]interface{} `json:"custom_fields,omitempty"`
+	Active                                    *bool                  `json:"active,omitempty"`
+}
+
+type RespRequesters struct {
+	Requesters []Requester `json:"requesters,omitempty"`
+}
+
 type RequesterSlice []Requester
 
 func (s RequesterSlice) Len() int { return len(s) }
@@ -122,15 +151,48 @@ func (manager requesterManager) Create(requester *Requester) (*Requester, error)
 	return output, nil
 }
 
-func (manager requesterManager) Update(id int64, requester *Requester) (*Requester, error) {
-	output := &Requester{}
+// Update sends the non-nil fields of the update. A nil entry in CustomFields
+// clears that custom field.
+func (manager requesterManager) Update(id int64, requester UpdateRequester) (*Requester, error) {
+	output := struct {
+		Requester *Requester `json:"requester,omitempty"`
+	}{}
 	jsonb, err := json.Marshal(requester)
 	if err != nil {
-		return output, err
+		return &Requester{}, err
 	}
 	err = manager.client.put(endpoints.requesters.update(id), jsonb, &output, http.StatusOK)
 	if err != nil {
 		return &Requester{}, err
 	}
+	if output.Requester == nil {
+		return &Requester{}, fmt.Errorf("updating requester %d: response holds no requester", id)
+	}
+	return output.Requester, nil
+}
+
+// UpdatedSince returns the requesters updated at or after the time.
+func (manager requesterManager) UpdatedSince(since time.Time) (RequesterSlice, error) {
+	query := querybuilder.BuildQuery()
+	query.UpdatedSince(since)
+	resp := RespRequesters{}
+	output := RequesterSlice{}
+	headers, err := manager.client.get(endpoints.requesters.search(query.URLSafe()), &resp)
+	if err != nil {
+		return RequesterSlice{}, err
+	}
+	output = append(output, resp.Requesters...)
+	for {
+		nextLink := manager.client.getNextLink(headers)
+		if nextLink == "" {
+			break
+		}
+		nextResp := RespRequesters{}
+		headers, err = manager.client.get(nextLink, &nextResp)
+		if err != nil {
+			return RequesterSlice{}, err
+		}
+		output = append(output, nextResp.Requesters...)
+	}
 	return output, nil
 }
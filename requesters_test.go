@@ -0,0 +1,116 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUpdateRequesterJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		update UpdateRequester
+		want   string
+	}{
+		{"empty", UpdateRequester{}, `{}`},
+		{"clear manager and location", UpdateRequester{ReportingManagerID: Null(), LocationID: Null()}, `{"reporting_manager_id":null,"location_id":null}`},
+		{"set manager", UpdateRequester{ReportingManagerID: NullableInt64(12)}, `{"reporting_manager_id":12}`},
+		{"zero and false", UpdateRequester{JobTitle: String(""), Active: Bool(false), DepartmentIDs: &[]int64{}}, `{"job_title":"","department_ids":[],"active":false}`},
+		{"can see all tickets", UpdateRequester{CanSeeAllTicketsFromAssociatedDepartments: Bool(false)}, `{"can_see_all_tickets_from_associated_departments":false}`},
+	}
+	for _, test := range tests {
+		jsonb, err := json.Marshal(test.update)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(jsonb) != test.want {
+			t.Errorf("%s: %s, want %s", test.name, jsonb, test.want)
+		}
+	}
+}
+
+func TestUpdateDepartmentJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		update UpdateDepartment
+		want   string
+	}{
+		{"empty", UpdateDepartment{}, `{}`},
+		{"clear head", UpdateDepartment{HeadUserID: Null()}, `{"head_user_id":null}`},
+		{"set prime", UpdateDepartment{PrimeUserID: NullableInt64(7), Description: String("")}, `{"description":"","prime_user_id":7}`},
+		{"clear domains", UpdateDepartment{Domains: &[]string{}}, `{"domains":[]}`},
+	}
+	for _, test := range tests {
+		jsonb, err := json.Marshal(test.update)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(jsonb) != test.want {
+			t.Errorf("%s: %s, want %s", test.name, jsonb, test.want)
+		}
+	}
+}
+
+func TestNullInt64Unmarshal(t *testing.T) {
+	tests := []struct {
+		json string
+		want NullInt64
+	}{
+		{`null`, NullInt64{}},
+		{`0`, NullInt64{Valid: true}},
+		{`42`, NullInt64{Int64: 42, Valid: true}},
+	}
+	for _, test := range tests {
+		got := NullInt64{Int64: 9, Valid: true}
+		if err := json.Unmarshal([]byte(test.json), &got); err != nil || got != test.want {
+			t.Errorf("Unmarshal(%s) = %+v, %v", test.json, got, err)
+		}
+	}
+}
+
+func TestRequesterUpdate(t *testing.T) {
+	body := ""
+	client := testClient(func(w http.ResponseWriter, r *http.Request) {
+		jsonb, _ := ioutil.ReadAll(r.Body)
+		body = string(jsonb)
+		w.Write([]byte(`{"requester": {"id": 5, "first_name": "Jane"}}`))
+	})
+	requester, err := client.Requesters.Update(5, UpdateRequester{ReportingManagerID: Null()})
+	if err != nil || requester.ID != 5 {
+		t.Fatalf("Update() = %+v, %v", requester, err)
+	}
+	if body != `{"reporting_manager_id":null}` {
+		t.Errorf("sent %s", body)
+	}
+
+	client = testClient(respond(http.StatusOK, `{"id": 5}`))
+	if _, err := client.Requesters.Update(5, UpdateRequester{}); err == nil || err.Error() != "updating requester 5: response holds no requester" {
+		t.Errorf("Update() without a requester error = %v", err)
+	}
+}
+
+func TestUpdatedSinceRequests(t *testing.T) {
+	queries := []string{}
+	client := testClient(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		w.Write([]byte(`{}`))
+	})
+	since := time.Date(2020, 5, 1, 9, 30, 0, 0, time.FixedZone("CEST", 2*3600))
+	if _, err := client.Requesters.UpdatedSince(since); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Tickets.UpdatedSinceAll(since); err != nil {
+		t.Fatal(err)
+	}
+	for _, query := range queries {
+		if !strings.Contains(query, "updated_since=2020-05-01T09%3A30%3A00%2B02%3A00") {
+			t.Errorf("query %s does not carry the escaped offset", query)
+		}
+	}
+	if len(queries) != 2 {
+		t.Errorf("queries = %v", queries)
+	}
+}
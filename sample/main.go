@@ -28,8 +28,8 @@ func main() {
 		Subject:     "Ticket Subject",
 		Description: "Ticket description.",
 		Email:       "identifier@domain.tld",
-		Status:      freshservice.StatusOpen.Value(),
-		Priority:    freshservice.PriorityLow.Value(),
+		Status:      freshservice.StatusOpen,
+		Priority:    freshservice.PriorityLow,
 	})
 	if err != nil {
 		panic(err)
@@ -26,7 +26,7 @@ type ServiceRequest struct {
 	CreatedAt      *time.Time  `json:"created_at"`
 	UpdatedAt      *time.Time  `json:"updated_at"`
 	Quantity       int         `json:"quantity"`
-	Stage          int         `json:"stage"`
+	Stage          SRStage     `json:"stage"`
 	Loaned         bool        `json:"loaned"`
 	CostPerRequest float32     `json:"cost_per_request"`
 	Remarks        string      `json:"remarks"`
@@ -0,0 +1,146 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Task is a checklist item attached to a ticket, change, problem or release.
+type Task struct {
+	ID           int64      `bson:"id" json:"id"`
+	AgentID      int64      `bson:"agent_id" json:"agent_id"`
+	GroupID      int64      `bson:"group_id" json:"group_id"`
+	Status       int        `bson:"status" json:"status"`
+	DueDate      *time.Time `bson:"due_date" json:"due_date"`
+	NotifyBefore int64      `bson:"notify_before" json:"notify_before"`
+	Title        string     `bson:"title" json:"title"`
+	Description  string     `bson:"description" json:"description"`
+	ClosedAt     *time.Time `bson:"closed_at" json:"closed_at"`
+	CreatedAt    *time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt    *time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// CreateTask is the payload for creating or updating a task. NotifyBefore is
+// the number of seconds before DueDate at which the assignee is reminded.
+type CreateTask struct {
+	AgentID      int64      `json:"agent_id,omitempty"`
+	GroupID      int64      `json:"group_id,omitempty"`
+	Status       int        `json:"status,omitempty"`
+	DueDate      *time.Time `json:"due_date,omitempty"`
+	NotifyBefore int64      `json:"notify_before,omitempty"`
+	Title        string     `json:"title,omitempty"`
+	Description  string     `json:"description,omitempty"`
+}
+
+// TaskStatus is the status of a task as numbered by the API: 1 open,
+// 2 in progress and 3 completed.
+type TaskStatus int
+
+const (
+	TaskStatusOpen TaskStatus = 1 + iota
+	TaskStatusInProgress
+	TaskStatusCompleted
+)
+
+func (s TaskStatus) Value() int {
+	return int(s)
+}
+
+type RespTasks struct {
+	Tasks []Task `json:"tasks,omitempty"`
+}
+
+type RespTask struct {
+	Task Task `json:"task,omitempty"`
+}
+
+type TaskSlice []Task
+
+func (s TaskSlice) Len() int { return len(s) }
+
+func (s TaskSlice) Less(i, j int) bool { return s[i].ID < s[j].ID }
+
+func (s TaskSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s TaskSlice) Print() {
+	for _, task := range s {
+		fmt.Println(task.Title)
+	}
+}
+
+// Completed reports whether every task is completed.
+func (s TaskSlice) Completed() bool {
+	return len(s.Pending()) == 0
+}
+
+// Pending returns the tasks that are not completed yet.
+func (s TaskSlice) Pending() TaskSlice {
+	pending := TaskSlice{}
+	for _, task := range s {
+		if task.Status != TaskStatusCompleted.Value() {
+			pending = append(pending, task)
+		}
+	}
+	return pending
+}
+
+func (c *ApiClient) getTasks(path string) (TaskSlice, error) {
+	resp := RespTasks{}
+	output := TaskSlice{}
+	headers, err := c.get(path, &resp)
+	if err != nil {
+		return TaskSlice{}, err
+	}
+	output = append(output, resp.Tasks...)
+
+	for {
+		nextLink := c.getNextLink(headers)
+		if nextLink == "" {
+			break
+		}
+		nextResp := RespTasks{}
+		headers, err = c.get(nextLink, &nextResp)
+		if err != nil {
+			return TaskSlice{}, err
+		}
+		output = append(output, nextResp.Tasks...)
+	}
+	return output, nil
+}
+
+func (c *ApiClient) getTask(path string) (Task, error) {
+	output := RespTask{}
+	_, err := c.get(path, &output)
+	if err != nil {
+		return Task{}, err
+	}
+	return output.Task, nil
+}
+
+func (c *ApiClient) postTask(path string, task CreateTask) (Task, error) {
+	output := RespTask{}
+	jsonb, err := json.Marshal(task)
+	if err != nil {
+		return Task{}, err
+	}
+	err = c.postJSON(path, jsonb, &output, http.StatusCreated)
+	if err != nil {
+		return Task{}, err
+	}
+	return output.Task, nil
+}
+
+func (c *ApiClient) putTask(path string, task CreateTask) (Task, error) {
+	output := RespTask{}
+	jsonb, err := json.Marshal(task)
+	if err != nil {
+		return Task{}, err
+	}
+	err = c.put(path, jsonb, &output, http.StatusOK)
+	if err != nil {
+		return Task{}, err
+	}
+	return output.Task, nil
+}
@@ -0,0 +1,62 @@
+package freshdesk
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestTaskStatusValues(t *testing.T) {
+	got := []int{TaskStatusOpen.Value(), TaskStatusInProgress.Value(), TaskStatusCompleted.Value()}
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("task status values = %v, want %v", got, want)
+	}
+}
+
+func TestTaskSliceCompleted(t *testing.T) {
+	tests := []struct {
+		name        string
+		tasks       TaskSlice
+		wantPending []int64
+	}{
+		{"no tasks", TaskSlice{}, []int64{}},
+		{"all completed", TaskSlice{{ID: 1, Status: TaskStatusCompleted.Value()}, {ID: 2, Status: TaskStatusCompleted.Value()}}, []int64{}},
+		{"some pending", TaskSlice{
+			{ID: 1, Status: TaskStatusOpen.Value()},
+			{ID: 2, Status: TaskStatusCompleted.Value()},
+			{ID: 3, Status: TaskStatusInProgress.Value()},
+		}, []int64{1, 3}},
+	}
+	for _, test := range tests {
+		pending := []int64{}
+		for _, task := range test.tasks.Pending() {
+			pending = append(pending, task.ID)
+		}
+		if !reflect.DeepEqual(pending, test.wantPending) {
+			t.Errorf("%s: Pending() = %v, want %v", test.name, pending, test.wantPending)
+		}
+		if got, want := test.tasks.Completed(), len(test.wantPending) == 0; got != want {
+			t.Errorf("%s: Completed() = %v, want %v", test.name, got, want)
+		}
+	}
+}
+
+func TestTicketTasksCompleted(t *testing.T) {
+	tests := []struct {
+		name     string
+		response http.HandlerFunc
+		want     bool
+		wantErr  bool
+	}{
+		{"completed", respond(http.StatusOK, `{"tasks": [{"id": 1, "status": 3}]}`), true, false},
+		{"pending", respond(http.StatusOK, `{"tasks": [{"id": 1, "status": 3}, {"id": 2, "status": 2}]}`), false, false},
+		{"no tasks", respond(http.StatusOK, `{"tasks": []}`), true, false},
+		{"server error", respond(http.StatusInternalServerError, ``), false, true},
+	}
+	for _, test := range tests {
+		got, err := testClient(test.response).Tickets.TasksCompleted(7)
+		if got != test.want || (err != nil) != test.wantErr {
+			t.Errorf("%s: TasksCompleted() = %v, %v", test.name, got, err)
+		}
+	}
+}
@@ -0,0 +1,314 @@
+package freshdesk
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// TicketEventType is the kind of change a TicketFeed reports.
+type TicketEventType string
+
+const (
+	TicketCreated       TicketEventType = "created"
+	TicketUpdated       TicketEventType = "updated"
+	TicketStatusChanged TicketEventType = "status_changed"
+	TicketAssigned      TicketEventType = "assigned"
+	TicketClosed        TicketEventType = "closed"
+)
+
+// TicketEvent is a change to a ticket seen by a TicketFeed. Previous is the
+// last state the feed saw, or nil if the ticket is new to it.
+type TicketEvent struct {
+	Type     TicketEventType
+	Ticket   Ticket
+	Previous *TicketSnapshot
+}
+
+// TicketSnapshot is the state of a ticket the feed remembers between polls.
+type TicketSnapshot struct {
+	UpdatedAt   time.Time `json:"updated_at"`
+	Status      Status    `json:"status"`
+	ResponderID int64     `json:"responder_id"`
+	GroupID     int64     `json:"group_id"`
+}
+
+// Checkpoint is the position of a TicketFeed. Tickets holds the snapshots of
+// the tickets updated within the overlap window before Since.
+type Checkpoint struct {
+	Since   time.Time                `json:"since"`
+	Tickets map[int64]TicketSnapshot `json:"tickets"`
+}
+
+// CheckpointStore persists the checkpoint of a TicketFeed between polls and
+// restarts. Load returns an empty Checkpoint if none was saved yet.
+type CheckpointStore interface {
+	Load() (Checkpoint, error)
+	Save(Checkpoint) error
+}
+
+// MemoryCheckpointStore keeps the checkpoint in memory.
+type MemoryCheckpointStore struct {
+	mutex      sync.Mutex
+	checkpoint Checkpoint
+}
+
+func (store *MemoryCheckpointStore) Load() (Checkpoint, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return store.checkpoint.copy(), nil
+}
+
+func (store *MemoryCheckpointStore) Save(checkpoint Checkpoint) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	store.checkpoint = checkpoint.copy()
+	return nil
+}
+
+// FileCheckpointStore keeps the checkpoint in a JSON file.
+type FileCheckpointStore struct {
+	Path string
+}
+
+func (store FileCheckpointStore) Load() (Checkpoint, error) {
+	checkpoint := Checkpoint{}
+	data, err := ioutil.ReadFile(store.Path)
+	if os.IsNotExist(err) {
+		return checkpoint, nil
+	}
+	if err != nil {
+		return checkpoint, err
+	}
+	err = json.Unmarshal(data, &checkpoint)
+	return checkpoint, err
+}
+
+// Save writes the checkpoint to a temporary file and renames it over Path,
+// so a crash never leaves a partial checkpoint.
+func (store FileCheckpointStore) Save(checkpoint Checkpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	file, err := ioutil.TempFile(filepath.Dir(store.Path), filepath.Base(store.Path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err = file.Write(data); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return err
+	}
+	if err = file.Close(); err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+	return os.Rename(file.Name(), store.Path)
+}
+
+// prune drops the snapshots of tickets last updated before the time. The next
+// poll cannot return them as duplicates, so only their history is lost: if
+// they change again they are reported as updated without a Previous state.
+func (checkpoint Checkpoint) prune(before time.Time) {
+	for id, snapshot := range checkpoint.Tickets {
+		if snapshot.UpdatedAt.Before(before) {
+			delete(checkpoint.Tickets, id)
+		}
+	}
+}
+
+func (checkpoint Checkpoint) copy() Checkpoint {
+	tickets := make(map[int64]TicketSnapshot, len(checkpoint.Tickets))
+	for id, snapshot := range checkpoint.Tickets {
+		tickets[id] = snapshot
+	}
+	checkpoint.Tickets = tickets
+	return checkpoint
+}
+
+// TicketFeed polls tickets updated since its checkpoint and reports the
+// changes as events.
+//
+// Each poll looks back Overlap before the checkpoint, so tickets whose
+// updated_at lags behind the clock are not missed, and drops tickets already
+// seen with the same updated_at. The checkpoint is only saved once every
+// event of a poll has been handled, so delivery is at least once.
+type TicketFeed struct {
+	Tickets TicketManager
+	Store   CheckpointStore
+	// Start is where a feed without a checkpoint begins. It defaults to the
+	// time of the first poll.
+	Start    time.Time
+	Interval time.Duration
+	Overlap  time.Duration
+	// Retention keeps ticket snapshots for this long beyond the overlap
+	// window. Status and assignment changes are only detected for tickets
+	// whose snapshot is still kept, at the cost of a larger checkpoint.
+	Retention time.Duration
+	// OnError is called with errors of polls made by Run and Events, which
+	// are retried at the next interval.
+	OnError func(error)
+}
+
+// NewTicketFeed returns a feed polling every minute with a five minute overlap.
+func NewTicketFeed(tickets TicketManager, store CheckpointStore) *TicketFeed {
+	return &TicketFeed{
+		Tickets:  tickets,
+		Store:    store,
+		Interval: time.Minute,
+		Overlap:  5 * time.Minute,
+	}
+}
+
+// Poll fetches the tickets updated since the checkpoint and calls handler for
+// each event in order of updated_at. If handler fails the checkpoint is left
+// unchanged and the error returned.
+func (feed *TicketFeed) Poll(handler func(TicketEvent) error) error {
+	checkpoint, err := feed.Store.Load()
+	if err != nil {
+		return err
+	}
+	checkpoint = checkpoint.copy()
+	if checkpoint.Since.IsZero() {
+		checkpoint.Since = feed.Start
+		if checkpoint.Since.IsZero() {
+			checkpoint.Since = time.Now()
+		}
+	}
+
+	since := checkpoint.Since.Add(-feed.Overlap)
+	tickets, err := feed.fetch(since)
+	if err != nil {
+		return err
+	}
+	for _, ticket := range tickets {
+		previous, known := checkpoint.Tickets[ticket.ID]
+		if known && !ticket.UpdatedAt.After(previous.UpdatedAt) {
+			continue
+		}
+		var events []TicketEvent
+		if known {
+			events = ticketEvents(ticket, &previous, since)
+		} else {
+			events = ticketEvents(ticket, nil, since)
+		}
+		for _, event := range events {
+			if err := handler(event); err != nil {
+				return err
+			}
+		}
+		checkpoint.Tickets[ticket.ID] = TicketSnapshot{
+			UpdatedAt:   *ticket.UpdatedAt,
+			Status:      ticket.Status,
+			ResponderID: ticket.ResponderID,
+			GroupID:     ticket.GroupID,
+		}
+		if ticket.UpdatedAt.After(checkpoint.Since) {
+			checkpoint.Since = *ticket.UpdatedAt
+		}
+	}
+	checkpoint.prune(checkpoint.Since.Add(-feed.Overlap - feed.Retention))
+	return feed.Store.Save(checkpoint)
+}
+
+// Run polls every Interval until ctx is done, calling handler for each event.
+func (feed *TicketFeed) Run(ctx context.Context, handler func(TicketEvent) error) error {
+	ticker := time.NewTicker(feed.Interval)
+	defer ticker.Stop()
+	for {
+		if err := feed.Poll(handler); err != nil && ctx.Err() == nil && feed.OnError != nil {
+			feed.OnError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Events runs the feed in the background and delivers its events on the
+// returned channel, which is closed once ctx is done.
+func (feed *TicketFeed) Events(ctx context.Context) <-chan TicketEvent {
+	events := make(chan TicketEvent)
+	go func() {
+		defer close(events)
+		feed.Run(ctx, func(event TicketEvent) error {
+			select {
+			case events <- event:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+	return events
+}
+
+// fetch returns every page of tickets updated since the time, oldest first.
+func (feed *TicketFeed) fetch(since time.Time) (TicketSlice, error) {
+	results, err := feed.Tickets.UpdatedSinceAll(since)
+	if err != nil {
+		return TicketSlice{}, err
+	}
+	tickets := results.Results
+	for results.NextURL != "" {
+		results, err = results.Next()
+		if err != nil {
+			return TicketSlice{}, err
+		}
+		tickets = append(tickets, results.Results...)
+	}
+
+	output := TicketSlice{}
+	for _, ticket := range tickets {
+		if ticket.UpdatedAt != nil {
+			output = append(output, ticket)
+		}
+	}
+	sort.SliceStable(output, func(i, j int) bool { return output[i].UpdatedAt.Before(*output[j].UpdatedAt) })
+	return output, nil
+}
+
+// ticketEvents describes how the ticket changed since the previous snapshot.
+// A ticket without a snapshot is new if it was created within the polled
+// window; it is then also reported as assigned or closed if it was created
+// that way. Otherwise there is nothing to compare with and it is merely
+// reported as updated.
+func ticketEvents(ticket Ticket, previous *TicketSnapshot, since time.Time) []TicketEvent {
+	event := func(eventType TicketEventType) TicketEvent {
+		return TicketEvent{Type: eventType, Ticket: ticket, Previous: previous}
+	}
+	if previous == nil {
+		if ticket.CreatedAt == nil || ticket.CreatedAt.Before(since) {
+			return []TicketEvent{event(TicketUpdated)}
+		}
+		events := []TicketEvent{event(TicketCreated)}
+		if ticket.Status == StatusClosed {
+			events = append(events, event(TicketClosed))
+		}
+		if ticket.ResponderID != 0 || ticket.GroupID != 0 {
+			events = append(events, event(TicketAssigned))
+		}
+		return events
+	}
+
+	events := []TicketEvent{event(TicketUpdated)}
+	if ticket.Status != previous.Status {
+		events = append(events, event(TicketStatusChanged))
+		if ticket.Status == StatusClosed {
+			events = append(events, event(TicketClosed))
+		}
+	}
+	if (ticket.ResponderID != previous.ResponderID && ticket.ResponderID != 0) ||
+		(ticket.GroupID != previous.GroupID && ticket.GroupID != 0) {
+		events = append(events, event(TicketAssigned))
+	}
+	return events
+}
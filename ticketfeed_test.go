@@ -0,0 +1,235 @@
+package freshdesk
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var feedStart = time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func at(minutes int) *time.Time {
+	t := feedStart.Add(time.Duration(minutes) * time.Minute)
+	return &t
+}
+
+// fakeFeedTickets serves UpdatedSinceAll from a fixed list of tickets, like
+// the API filters them by updated_at.
+type fakeFeedTickets struct {
+	TicketManager
+	tickets TicketSlice
+	since   []time.Time
+}
+
+func (f *fakeFeedTickets) UpdatedSinceAll(since time.Time) (TicketResults, error) {
+	f.since = append(f.since, since)
+	results := TicketSlice{}
+	for _, ticket := range f.tickets {
+		if !ticket.UpdatedAt.Before(since) {
+			results = append(results, ticket)
+		}
+	}
+	return TicketResults{Results: results}, nil
+}
+
+// set replaces or adds the ticket.
+func (f *fakeFeedTickets) set(ticket Ticket) {
+	for i := range f.tickets {
+		if f.tickets[i].ID == ticket.ID {
+			f.tickets[i] = ticket
+			return
+		}
+	}
+	f.tickets = append(f.tickets, ticket)
+}
+
+// poll runs one poll and describes its events as "id:type" strings.
+func poll(t *testing.T, feed *TicketFeed) []string {
+	t.Helper()
+	events := []string{}
+	err := feed.Poll(func(event TicketEvent) error {
+		events = append(events, describeEvent(event))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return events
+}
+
+func describeEvent(event TicketEvent) string {
+	return fmt.Sprintf("%d:%s", event.Ticket.ID, event.Type)
+}
+
+func TestTicketEvents(t *testing.T) {
+	since := *at(0)
+	tests := []struct {
+		name     string
+		ticket   Ticket
+		previous *TicketSnapshot
+		want     []TicketEventType
+	}{
+		{"created", Ticket{CreatedAt: at(1), Status: StatusOpen}, nil, []TicketEventType{TicketCreated}},
+		{"created at window start", Ticket{CreatedAt: at(0), Status: StatusOpen}, nil, []TicketEventType{TicketCreated}},
+		{"created assigned", Ticket{CreatedAt: at(1), Status: StatusOpen, GroupID: 3}, nil, []TicketEventType{TicketCreated, TicketAssigned}},
+		{"created closed", Ticket{CreatedAt: at(1), Status: StatusClosed, ResponderID: 9}, nil, []TicketEventType{TicketCreated, TicketClosed, TicketAssigned}},
+		{"unknown old ticket", Ticket{CreatedAt: at(-60), Status: StatusClosed, GroupID: 3}, nil, []TicketEventType{TicketUpdated}},
+		{"no creation time", Ticket{Status: StatusOpen}, nil, []TicketEventType{TicketUpdated}},
+		{"updated", Ticket{Status: StatusOpen, GroupID: 3}, &TicketSnapshot{Status: StatusOpen, GroupID: 3}, []TicketEventType{TicketUpdated}},
+		{"status changed", Ticket{Status: StatusPending}, &TicketSnapshot{Status: StatusOpen}, []TicketEventType{TicketUpdated, TicketStatusChanged}},
+		{"closed", Ticket{Status: StatusClosed}, &TicketSnapshot{Status: StatusResolved}, []TicketEventType{TicketUpdated, TicketStatusChanged, TicketClosed}},
+		{"assigned agent", Ticket{Status: StatusOpen, ResponderID: 9}, &TicketSnapshot{Status: StatusOpen}, []TicketEventType{TicketUpdated, TicketAssigned}},
+		{"reassigned group", Ticket{Status: StatusOpen, GroupID: 4}, &TicketSnapshot{Status: StatusOpen, GroupID: 3}, []TicketEventType{TicketUpdated, TicketAssigned}},
+		{"unassigned", Ticket{Status: StatusOpen}, &TicketSnapshot{Status: StatusOpen, ResponderID: 9}, []TicketEventType{TicketUpdated}},
+	}
+	for _, test := range tests {
+		got := []TicketEventType{}
+		for _, event := range ticketEvents(test.ticket, test.previous, since) {
+			got = append(got, event.Type)
+			if event.Previous != test.previous {
+				t.Errorf("%s: Previous = %v, want %v", test.name, event.Previous, test.previous)
+			}
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: events = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestTicketFeedPoll(t *testing.T) {
+	tickets := &fakeFeedTickets{}
+	store := &MemoryCheckpointStore{}
+	feed := NewTicketFeed(tickets, store)
+	feed.Start = *at(10)
+
+	tickets.set(Ticket{ID: 1, Status: StatusOpen, CreatedAt: at(-120), UpdatedAt: at(9)})
+	tickets.set(Ticket{ID: 2, Status: StatusOpen, GroupID: 3, CreatedAt: at(11), UpdatedAt: at(11)})
+	tickets.set(Ticket{ID: 3, Status: StatusOpen, CreatedAt: at(-120), UpdatedAt: at(-60)})
+
+	if got, want := poll(t, feed), []string{"1:updated", "2:created", "2:assigned"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("first poll = %v, want %v", got, want)
+	}
+	if got, want := tickets.since[0], *at(5); !got.Equal(want) {
+		t.Errorf("first poll since %v, want %v", got, want)
+	}
+
+	// The overlap returns the same tickets again; they are not reported twice.
+	if got := poll(t, feed); len(got) != 0 {
+		t.Errorf("repeated poll = %v", got)
+	}
+	if got, want := tickets.since[1], *at(6); !got.Equal(want) {
+		t.Errorf("second poll since %v, want %v", got, want)
+	}
+
+	tickets.set(Ticket{ID: 2, Status: StatusClosed, GroupID: 3, ResponderID: 7, CreatedAt: at(11), UpdatedAt: at(12)})
+	if got, want := poll(t, feed), []string{"2:updated", "2:status_changed", "2:closed", "2:assigned"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("third poll = %v, want %v", got, want)
+	}
+
+	checkpoint, _ := store.Load()
+	if !checkpoint.Since.Equal(*at(12)) {
+		t.Errorf("checkpoint since %v, want %v", checkpoint.Since, at(12))
+	}
+	if len(checkpoint.Tickets) != 2 {
+		t.Errorf("checkpoint tickets = %v", checkpoint.Tickets)
+	}
+}
+
+func TestTicketFeedHandlerError(t *testing.T) {
+	tickets := &fakeFeedTickets{}
+	store := &MemoryCheckpointStore{}
+	feed := NewTicketFeed(tickets, store)
+	feed.Start = *at(0)
+	tickets.set(Ticket{ID: 1, Status: StatusOpen, CreatedAt: at(1), UpdatedAt: at(1)})
+	tickets.set(Ticket{ID: 2, Status: StatusOpen, CreatedAt: at(2), UpdatedAt: at(2)})
+
+	failure := errors.New("downstream unavailable")
+	err := feed.Poll(func(event TicketEvent) error {
+		if event.Ticket.ID == 2 {
+			return failure
+		}
+		return nil
+	})
+	if err != failure {
+		t.Fatalf("Poll() error = %v", err)
+	}
+	checkpoint, _ := store.Load()
+	if !checkpoint.Since.IsZero() || len(checkpoint.Tickets) != 0 {
+		t.Errorf("checkpoint saved after failure: %+v", checkpoint)
+	}
+
+	// Delivery is at least once: the next poll repeats the whole batch.
+	if got, want := poll(t, feed), []string{"1:created", "2:created"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("retry = %v, want %v", got, want)
+	}
+}
+
+func TestTicketFeedPrunesSnapshots(t *testing.T) {
+	tickets := &fakeFeedTickets{}
+	store := &MemoryCheckpointStore{}
+	feed := NewTicketFeed(tickets, store)
+	feed.Start = *at(0)
+	tickets.set(Ticket{ID: 1, Status: StatusOpen, CreatedAt: at(1), UpdatedAt: at(1)})
+	poll(t, feed)
+
+	tickets.set(Ticket{ID: 2, Status: StatusOpen, CreatedAt: at(30), UpdatedAt: at(30)})
+	poll(t, feed)
+	checkpoint, _ := store.Load()
+	if _, ok := checkpoint.Tickets[1]; ok {
+		t.Error("snapshot older than the overlap window kept")
+	}
+	if _, ok := checkpoint.Tickets[2]; !ok {
+		t.Error("snapshot within the overlap window dropped")
+	}
+
+	// A pruned ticket that changes again has no history to compare with.
+	tickets.set(Ticket{ID: 1, Status: StatusPending, CreatedAt: at(1), UpdatedAt: at(31)})
+	if got, want := poll(t, feed), []string{"1:updated"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("poll = %v, want %v", got, want)
+	}
+
+	// With a retention the history survives.
+	feed.Retention = time.Hour
+	tickets.set(Ticket{ID: 1, Status: StatusOpen, CreatedAt: at(1), UpdatedAt: at(32)})
+	poll(t, feed)
+	tickets.set(Ticket{ID: 1, Status: StatusClosed, CreatedAt: at(1), UpdatedAt: at(60)})
+	if got, want := poll(t, feed), []string{"1:updated", "1:status_changed", "1:closed"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("poll with retention = %v, want %v", got, want)
+	}
+}
+
+func TestFileCheckpointStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ticketfeed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	store := FileCheckpointStore{Path: filepath.Join(dir, "checkpoint.json")}
+	empty, err := store.Load()
+	if err != nil || !empty.Since.IsZero() {
+		t.Fatalf("Load() of a missing file = %+v, %v", empty, err)
+	}
+	checkpoint := Checkpoint{
+		Since:   *at(5),
+		Tickets: map[int64]TicketSnapshot{7: {UpdatedAt: *at(4), Status: StatusPending, ResponderID: 3, GroupID: 2}},
+	}
+	if err := store.Save(checkpoint); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.Since.Equal(checkpoint.Since) || !reflect.DeepEqual(loaded.Tickets, checkpoint.Tickets) {
+		t.Errorf("Load() = %+v, want %+v", loaded, checkpoint)
+	}
+	matches, _ := filepath.Glob(filepath.Join(filepath.Dir(store.Path), "*"))
+	if len(matches) != 1 {
+		t.Errorf("files left behind: %v", matches)
+	}
+}
@@ -0,0 +1,397 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type TicketFieldManager interface {
+	All() (TicketFieldSlice, error)
+	Metadata() (TicketMetadata, error)
+}
+
+type ticketFieldManager struct {
+	client *ApiClient
+}
+
+func newTicketFieldManager(client *ApiClient) ticketFieldManager {
+	return ticketFieldManager{
+		client,
+	}
+}
+
+// TicketField describes a field of the ticket form. Default fields such as
+// status and priority have DefaultField set; custom fields are stored in
+// CustomFields under Name.
+type TicketField struct {
+	ID                   int64               `json:"id"`
+	Name                 string              `json:"name"`
+	Label                string              `json:"label"`
+	Description          string              `json:"description"`
+	FieldType            string              `json:"field_type"`
+	Position             int                 `json:"position"`
+	Level                int                 `json:"level"`
+	DefaultField         bool                `json:"default_field"`
+	RequiredForAgents    bool                `json:"required_for_agents"`
+	RequiredForCustomers bool                `json:"required_for_customers"`
+	RequiredForClosure   bool                `json:"required_for_closure"`
+	DisplayedToCustomers bool                `json:"displayed_to_customers"`
+	Choices              []TicketFieldChoice `json:"choices"`
+	NestedFields         []TicketField       `json:"nested_fields"`
+	CreatedAt            *time.Time          `json:"created_at"`
+	UpdatedAt            *time.Time          `json:"updated_at"`
+}
+
+// TicketFieldChoice is an allowed value of a dropdown or nested field. For
+// default fields such as status, ID holds the numeric value sent to the API.
+type TicketFieldChoice struct {
+	ID            int64               `json:"id"`
+	Value         string              `json:"value"`
+	NestedOptions []TicketFieldChoice `json:"nested_options"`
+}
+
+const (
+	TicketFieldTypeText      = "custom_text"
+	TicketFieldTypeParagraph = "custom_paragraph"
+	TicketFieldTypeNumber    = "custom_number"
+	TicketFieldTypeDecimal   = "custom_decimal"
+	TicketFieldTypeCheckbox  = "custom_checkbox"
+	TicketFieldTypeDate      = "custom_date"
+	TicketFieldTypeDropdown  = "custom_dropdown"
+	TicketFieldTypeNested    = "nested_field"
+)
+
+type RespTicketFields struct {
+	TicketFields []TicketField `json:"ticket_fields,omitempty"`
+}
+
+type TicketFieldSlice []TicketField
+
+func (s TicketFieldSlice) Len() int { return len(s) }
+
+func (s TicketFieldSlice) Less(i, j int) bool { return s[i].Position < s[j].Position }
+
+func (s TicketFieldSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s TicketFieldSlice) Print() {
+	for _, field := range s {
+		fmt.Println(field.Name, field.FieldType)
+	}
+}
+
+// Find returns the field with the given name.
+func (s TicketFieldSlice) Find(name string) (TicketField, bool) {
+	for _, field := range s {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return TicketField{}, false
+}
+
+// RequiredFor reports whether the field must be set on a ticket in the given
+// status when saved by an agent.
+func (field TicketField) RequiredFor(status int) bool {
+	if field.RequiredForAgents {
+		return true
+	}
+	return field.RequiredForClosure && (status == StatusResolved.Value() || status == StatusClosed.Value())
+}
+
+// TicketFieldError describes a value rejected by the ticket form.
+type TicketFieldError struct {
+	Field   string
+	Message string
+}
+
+func (e TicketFieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidationErrors collects every TicketFieldError of a payload.
+type ValidationErrors []TicketFieldError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// defaultTicketFieldKeys maps default form fields to their CreateTicket keys.
+var defaultTicketFieldKeys = map[string][]string{
+	"requester":     {"requester_id", "email", "phone"},
+	"subject":       {"subject"},
+	"ticket_type":   {"type"},
+	"status":        {"status"},
+	"priority":      {"priority"},
+	"source":        {"source"},
+	"urgency":       {"urgency"},
+	"impact":        {"impact"},
+	"group":         {"group_id"},
+	"agent":         {"responder_id"},
+	"department":    {"department_id"},
+	"description":   {"description"},
+	"category":      {"category"},
+	"sub_category":  {"sub_category"},
+	"item_category": {"item_category"},
+}
+
+// ValidateCreate checks a new ticket against the form: required fields, value
+// types, dropdown choices and nested field dependencies. It returns nil or
+// ValidationErrors listing every problem found.
+func (s TicketFieldSlice) ValidateCreate(ticket CreateTicket) error {
+	values, err := ticketValues(ticket)
+	if err != nil {
+		return err
+	}
+	return s.validate(values, true)
+}
+
+// ValidateUpdate checks an update payload. Only the fields being changed are
+// type checked; required fields may also be satisfied by the current ticket.
+func (s TicketFieldSlice) ValidateUpdate(update CreateTicket, current Ticket) error {
+	values, err := ticketValues(update)
+	if err != nil {
+		return err
+	}
+	existing, err := ticketValues(current)
+	if err != nil {
+		return err
+	}
+	errs := ValidationErrors{}
+	if err, ok := s.validate(values, false).(ValidationErrors); ok {
+		errs = append(errs, err...)
+	}
+	merged := map[string]interface{}{}
+	for key, value := range existing {
+		merged[key] = value
+	}
+	for key, value := range values {
+		merged[key] = value
+	}
+	errs = append(errs, s.missing(merged)...)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func (s TicketFieldSlice) validate(values map[string]interface{}, checkRequired bool) error {
+	errs := ValidationErrors{}
+	for _, field := range s {
+		value, ok := fieldValue(field, values)
+		if !ok {
+			continue
+		}
+		if field.FieldType == TicketFieldTypeNested {
+			errs = append(errs, field.validateNested(values)...)
+			continue
+		}
+		if err := field.check(value); err != "" {
+			errs = append(errs, TicketFieldError{field.Name, err})
+		}
+	}
+	if checkRequired {
+		errs = append(errs, s.missing(values)...)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func (s TicketFieldSlice) missing(values map[string]interface{}) ValidationErrors {
+	errs := ValidationErrors{}
+	status := StatusOpen.Value()
+	if value, ok := values["status"].(float64); ok {
+		status = int(value)
+	}
+	for _, field := range s {
+		if !field.RequiredFor(status) {
+			continue
+		}
+		if _, ok := fieldValue(field, values); !ok {
+			errs = append(errs, TicketFieldError{field.Name, "is required"})
+		}
+	}
+	return errs
+}
+
+// check validates a single value against the field type, returning a message
+// describing the problem or an empty string.
+func (field TicketField) check(value interface{}) string {
+	switch field.FieldType {
+	case TicketFieldTypeText, TicketFieldTypeParagraph:
+		if _, ok := value.(string); !ok {
+			return fmt.Sprintf("expected text, got %T", value)
+		}
+	case TicketFieldTypeNumber:
+		number, ok := value.(float64)
+		if !ok || number != math.Trunc(number) {
+			return fmt.Sprintf("expected a whole number, got %v", value)
+		}
+	case TicketFieldTypeDecimal:
+		if _, ok := value.(float64); !ok {
+			return fmt.Sprintf("expected a number, got %v", value)
+		}
+	case TicketFieldTypeCheckbox:
+		if _, ok := value.(bool); !ok {
+			return fmt.Sprintf("expected true or false, got %v", value)
+		}
+	case TicketFieldTypeDate:
+		text, _ := value.(string)
+		if _, err := time.Parse("2006-01-02", text); err != nil {
+			if _, err := time.Parse(time.RFC3339, text); err != nil {
+				return fmt.Sprintf("expected a date, got %v", value)
+			}
+		}
+	}
+	if len(field.Choices) > 0 && field.FieldType != TicketFieldTypeNested {
+		if _, ok := findChoice(field.Choices, value); !ok {
+			return fmt.Sprintf("%v is not one of the allowed choices", value)
+		}
+	}
+	return ""
+}
+
+// validateNested checks that each level of a nested field is one of the
+// options allowed by the level above it.
+func (field TicketField) validateNested(values map[string]interface{}) ValidationErrors {
+	errs := ValidationErrors{}
+	value, _ := fieldValue(field, values)
+	choice, ok := findChoice(field.Choices, value)
+	if !ok {
+		return append(errs, TicketFieldError{field.Name, fmt.Sprintf("%v is not one of the allowed choices", value)})
+	}
+	parent := field.Name
+	for _, nested := range field.NestedFields {
+		value, present := fieldValue(nested, values)
+		if !present {
+			if len(choice.NestedOptions) > 0 && nested.RequiredForAgents {
+				errs = append(errs, TicketFieldError{nested.Name, "is required"})
+			}
+			break
+		}
+		next, ok := findChoice(choice.NestedOptions, value)
+		if !ok {
+			errs = append(errs, TicketFieldError{nested.Name, fmt.Sprintf("%v is not allowed for %s %q", value, parent, choice.Value)})
+			break
+		}
+		parent, choice = nested.Name, next
+	}
+	return errs
+}
+
+func findChoice(choices []TicketFieldChoice, value interface{}) (TicketFieldChoice, bool) {
+	for _, choice := range choices {
+		switch v := value.(type) {
+		case float64:
+			if float64(choice.ID) == v || choice.Value == strconv.FormatFloat(v, 'f', -1, 64) {
+				return choice, true
+			}
+		case string:
+			if choice.Value == v {
+				return choice, true
+			}
+		case []interface{}:
+			// sub_category and item_category are sent as single element lists.
+			if len(v) == 1 {
+				if found, ok := findChoice([]TicketFieldChoice{choice}, v[0]); ok {
+					return found, true
+				}
+			}
+		}
+	}
+	return TicketFieldChoice{}, false
+}
+
+// fieldValue looks the field up in the flattened payload. Custom fields are
+// read from their customFieldKey, so they never shadow a default field of the
+// same name.
+func fieldValue(field TicketField, values map[string]interface{}) (interface{}, bool) {
+	keys := []string{customFieldKey(field.Name)}
+	if field.DefaultField {
+		var ok bool
+		if keys, ok = defaultTicketFieldKeys[field.Name]; !ok {
+			keys = []string{field.Name}
+		}
+	}
+	for _, key := range keys {
+		if value, ok := values[key]; ok && value != nil && value != "" {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// customFieldKey is the key of a custom field in the map built by ticketValues.
+func customFieldKey(name string) string {
+	return "custom_fields." + name
+}
+
+// ticketValues flattens a ticket payload and its custom fields into one map
+// holding the JSON representation of each value. Custom fields are stored
+// under their customFieldKey. Zero IDs, properties and empty lists among the
+// default fields are how Ticket represents null, so they are left out; custom
+// field values are kept as they are, zero included. Urgency is normalised to
+// its numeric value.
+func ticketValues(ticket interface{}) (map[string]interface{}, error) {
+	jsonb, err := json.Marshal(ticket)
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]interface{}{}
+	if err := json.Unmarshal(jsonb, &values); err != nil {
+		return nil, err
+	}
+	for key, value := range values {
+		if number, ok := value.(float64); ok && number == 0 {
+			delete(values, key)
+		}
+		if list, ok := value.([]interface{}); ok && len(list) == 0 {
+			delete(values, key)
+		}
+	}
+	if urgency, ok := values["urgency"].(string); ok && urgency != "" {
+		if value, err := parseChoice("urgency", urgency, DefaultTicketMetadata().Urgencies); err == nil {
+			values["urgency"] = float64(value)
+		}
+	}
+	if custom, ok := values["custom_fields"].(map[string]interface{}); ok {
+		delete(values, "custom_fields")
+		for key, value := range custom {
+			values[customFieldKey(key)] = value
+		}
+	}
+	return values, nil
+}
+
+func (manager ticketFieldManager) All() (TicketFieldSlice, error) {
+	resp := RespTicketFields{}
+	output := TicketFieldSlice{}
+	headers, err := manager.client.get(endpoints.ticketFields.all, &resp)
+	if err != nil {
+		return TicketFieldSlice{}, err
+	}
+	output = append(output, resp.TicketFields...)
+
+	for {
+		nextLink := manager.client.getNextLink(headers)
+		if nextLink == "" {
+			break
+		}
+		nextResp := RespTicketFields{}
+		headers, err = manager.client.get(nextLink, &nextResp)
+		if err != nil {
+			return TicketFieldSlice{}, err
+		}
+		output = append(output, nextResp.TicketFields...)
+	}
+	return output, nil
+}
@@ -0,0 +1,204 @@
+package freshdesk
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func testTicketFields() TicketFieldSlice {
+	return TicketFieldSlice{
+		{Name: "requester", DefaultField: true, RequiredForAgents: true},
+		{Name: "subject", DefaultField: true, RequiredForAgents: true},
+		{Name: "status", DefaultField: true, FieldType: "default_status", Choices: []TicketFieldChoice{
+			{ID: 2, Value: "Open"}, {ID: 3, Value: "Pending"}, {ID: 4, Value: "Resolved"}, {ID: 5, Value: "Closed"},
+		}},
+		{Name: "urgency", DefaultField: true, FieldType: "default_urgency", Choices: []TicketFieldChoice{
+			{ID: 1, Value: "Low"}, {ID: 2, Value: "Medium"}, {ID: 3, Value: "High"},
+		}},
+		{Name: "group", DefaultField: true, RequiredForClosure: true},
+		{Name: "seats", FieldType: TicketFieldTypeNumber, RequiredForAgents: true},
+		{Name: "cost", FieldType: TicketFieldTypeDecimal},
+		{Name: "vip", FieldType: TicketFieldTypeCheckbox},
+		{Name: "go_live", FieldType: TicketFieldTypeDate},
+		{Name: "team", FieldType: TicketFieldTypeDropdown, Choices: []TicketFieldChoice{{Value: "Network"}, {Value: "Desktop"}}},
+		{Name: "region", FieldType: TicketFieldTypeNested,
+			Choices: []TicketFieldChoice{
+				{Value: "EU", NestedOptions: []TicketFieldChoice{
+					{Value: "Belgium", NestedOptions: []TicketFieldChoice{{Value: "Ghent"}}},
+				}},
+				{Value: "US"},
+			},
+			NestedFields: []TicketField{
+				{Name: "country", RequiredForAgents: true},
+				{Name: "city"},
+			},
+		},
+	}
+}
+
+// fieldNames lists the fields of the validation errors, sorted.
+func fieldNames(err error) string {
+	if err == nil {
+		return ""
+	}
+	errs, ok := err.(ValidationErrors)
+	if !ok {
+		return "unexpected error: " + err.Error()
+	}
+	names := []string{}
+	for _, fieldErr := range errs {
+		names = append(names, fieldErr.Field)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+func validTicket(custom map[string]interface{}) CreateTicket {
+	fields := map[string]interface{}{"seats": 4}
+	for key, value := range custom {
+		fields[key] = value
+	}
+	return CreateTicket{Email: "jane@example.com", Subject: "New laptop", Status: StatusOpen, CustomFields: fields}
+}
+
+func TestValidateCreate(t *testing.T) {
+	fields := testTicketFields()
+	tests := []struct {
+		name   string
+		ticket CreateTicket
+		want   string
+	}{
+		{"valid", validTicket(nil), ""},
+		{"custom number zero", validTicket(map[string]interface{}{"seats": 0}), ""},
+		{"custom decimal zero", validTicket(map[string]interface{}{"cost": 0.0}), ""},
+		{"checkbox false", validTicket(map[string]interface{}{"vip": false}), ""},
+		{"missing required", CreateTicket{Status: StatusOpen}, "requester,seats,subject"},
+		{"custom field nil", validTicket(map[string]interface{}{"seats": nil}), "seats"},
+		{"custom field empty", validTicket(map[string]interface{}{"seats": ""}), "seats"},
+		{"requester by id", CreateTicket{RequesterID: 9, Subject: "x", CustomFields: map[string]interface{}{"seats": 1}}, ""},
+		{"fractional number", validTicket(map[string]interface{}{"seats": 1.5}), "seats"},
+		{"text as number", validTicket(map[string]interface{}{"cost": "12"}), "cost"},
+		{"checkbox as text", validTicket(map[string]interface{}{"vip": "yes"}), "vip"},
+		{"date", validTicket(map[string]interface{}{"go_live": "2020-05-01"}), ""},
+		{"bad date", validTicket(map[string]interface{}{"go_live": "tomorrow"}), "go_live"},
+		{"dropdown", validTicket(map[string]interface{}{"team": "Network"}), ""},
+		{"bad dropdown", validTicket(map[string]interface{}{"team": "Facilities"}), "team"},
+		{"unknown status", CreateTicket{Email: "a@b.c", Subject: "x", Status: Status(9), CustomFields: map[string]interface{}{"seats": 1}}, "status"},
+		{"urgency number", CreateTicket{Email: "a@b.c", Subject: "x", Urgency: "3", CustomFields: map[string]interface{}{"seats": 1}}, ""},
+		{"urgency name", CreateTicket{Email: "a@b.c", Subject: "x", Urgency: "Medium", CustomFields: map[string]interface{}{"seats": 1}}, ""},
+		{"bad urgency", CreateTicket{Email: "a@b.c", Subject: "x", Urgency: "9", CustomFields: map[string]interface{}{"seats": 1}}, "urgency"},
+		{"closed without group", CreateTicket{Email: "a@b.c", Subject: "x", Status: StatusClosed, CustomFields: map[string]interface{}{"seats": 1}}, "group"},
+		{"closed with group", CreateTicket{Email: "a@b.c", Subject: "x", Status: StatusClosed, GroupID: 3, CustomFields: map[string]interface{}{"seats": 1}}, ""},
+		{"nested", validTicket(map[string]interface{}{"region": "EU", "country": "Belgium", "city": "Ghent"}), ""},
+		{"nested leaf", validTicket(map[string]interface{}{"region": "US"}), ""},
+		{"nested missing level", validTicket(map[string]interface{}{"region": "EU"}), "country"},
+		{"nested wrong level", validTicket(map[string]interface{}{"region": "EU", "country": "France"}), "country"},
+		{"nested wrong leaf", validTicket(map[string]interface{}{"region": "EU", "country": "Belgium", "city": "Paris"}), "city"},
+		{"nested bad root", validTicket(map[string]interface{}{"region": "Asia"}), "region"},
+		{"every error at once", CreateTicket{Status: Status(9), CustomFields: map[string]interface{}{"vip": 1, "team": "x"}}, "requester,seats,status,subject,team,vip"},
+	}
+	for _, test := range tests {
+		if got := fieldNames(fields.ValidateCreate(test.ticket)); got != test.want {
+			t.Errorf("%s: errors on %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestValidateUpdate(t *testing.T) {
+	fields := testTicketFields()
+	current := Ticket{
+		Email:        "jane@example.com",
+		Subject:      "New laptop",
+		Status:       StatusOpen,
+		CustomFields: map[string]interface{}{"seats": 0.0},
+	}
+	tests := []struct {
+		name   string
+		update CreateTicket
+		want   string
+	}{
+		{"required from current", CreateTicket{Status: StatusPending}, ""},
+		{"type checked", CreateTicket{CustomFields: map[string]interface{}{"vip": "yes"}}, "vip"},
+		{"closure needs group", CreateTicket{Status: StatusClosed}, "group"},
+		{"closure with group", CreateTicket{Status: StatusClosed, GroupID: 4}, ""},
+		{"clearing required", CreateTicket{CustomFields: map[string]interface{}{"seats": nil}}, "seats"},
+	}
+	for _, test := range tests {
+		if got := fieldNames(fields.ValidateUpdate(test.update, current)); got != test.want {
+			t.Errorf("%s: errors on %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestTicketValues(t *testing.T) {
+	values, err := ticketValues(Ticket{ID: 1, Urgency: "High", CustomFields: map[string]interface{}{"seats": 0, "tags": []string{}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := values["group_id"]; ok {
+		t.Error("zero group_id kept")
+	}
+	if values["custom_fields.seats"] != 0.0 {
+		t.Errorf("seats = %v, want 0", values["custom_fields.seats"])
+	}
+	if list, ok := values["custom_fields.tags"].([]interface{}); !ok || len(list) != 0 {
+		t.Errorf("custom empty list = %#v", values["custom_fields.tags"])
+	}
+	if values["urgency"] != 3.0 {
+		t.Errorf("urgency = %#v, want 3", values["urgency"])
+	}
+}
+
+// TestValidateCustomFieldNamedLikeDefault checks that custom fields named
+// after default fields are validated on their own values.
+func TestValidateCustomFieldNamedLikeDefault(t *testing.T) {
+	fields := append(testTicketFields(),
+		TicketField{Name: "status", FieldType: TicketFieldTypeDropdown, Choices: []TicketFieldChoice{{Value: "Green"}, {Value: "Red"}}},
+		TicketField{Name: "subject", FieldType: TicketFieldTypeCheckbox, RequiredForAgents: true},
+	)
+	tests := []struct {
+		name   string
+		ticket CreateTicket
+		want   string
+	}{
+		{"both set", validTicket(map[string]interface{}{"status": "Red", "subject": true}), ""},
+		{"custom missing", validTicket(nil), "subject"},
+		{"custom invalid", validTicket(map[string]interface{}{"status": "Blue", "subject": false}), "status"},
+		{"default invalid", CreateTicket{Email: "a@b.c", Subject: "x", Status: Status(9),
+			CustomFields: map[string]interface{}{"seats": 1, "status": "Red", "subject": true}}, "status"},
+		{"default missing", CreateTicket{Email: "a@b.c",
+			CustomFields: map[string]interface{}{"seats": 1, "status": "Red", "subject": true}}, "subject"},
+	}
+	for _, test := range tests {
+		if got := fieldNames(fields.ValidateCreate(test.ticket)); got != test.want {
+			t.Errorf("%s: errors on %q, want %q", test.name, got, test.want)
+		}
+	}
+
+	metadata := NewTicketMetadata(fields)
+	if want := DefaultTicketMetadata().Statuses; !reflect.DeepEqual(metadata.Statuses, want) {
+		t.Errorf("custom status field changed the statuses to %v", metadata.Statuses)
+	}
+}
+
+func TestTicketFieldsAll(t *testing.T) {
+	client := testClient(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			w.Header().Set("Link", `<https://acme.freshservice.com/api/v2/ticket_form_fields?page=2>; rel="next"`)
+			page = "1"
+		}
+		fmt.Fprintf(w, `{"ticket_fields": [{"id": %s, "name": "field_%s"}]}`, page, page)
+	})
+	fields, err := client.TicketFields.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fields) != 2 || fields[0].Name != "field_1" || fields[1].Name != "field_2" {
+		t.Errorf("All() = %+v, want both pages", fields)
+	}
+}
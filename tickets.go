@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/nextlinktechnology/go-freshservice/querybuilder"
@@ -15,10 +16,37 @@ type TicketManager interface {
 	All() (TicketResults, error)
 	Create(CreateTicket) (Ticket, error)
 	View(int64) (Ticket, error)
+	ViewWith(int64, ...TicketInclude) (Ticket, error)
+	Update(int64, CreateTicket) (Ticket, error)
+	AssociateProblem(int64, int64) (Ticket, error)
+	AssociateChange(int64, int64) (Ticket, error)
 	Search(querybuilder.Query) (TicketResults, error)
 	Reply(int64, CreateConversation) (Conversation, error)
+	AddNote(int64, CreateConversation) (Conversation, error)
+	Forward(int64, []string, string) (Conversation, error)
+	Merge(int64, []int64, string) error
 	Conversations(int64) (ConversationSlice, error)
-	UpdatedSinceAll(string) (TicketResults, error)
+	UpdatedSinceAll(time.Time) (TicketResults, error)
+	Tasks(int64) (TaskSlice, error)
+	ViewTask(int64, int64) (Task, error)
+	CreateTask(int64, CreateTask) (Task, error)
+	UpdateTask(int64, int64, CreateTask) (Task, error)
+	DeleteTask(int64, int64) error
+	TasksCompleted(int64) (bool, error)
+	TimeEntries(int64) (TimeEntrySlice, error)
+	ViewTimeEntry(int64, int64) (TimeEntry, error)
+	CreateTimeEntry(int64, CreateTimeEntry) (TimeEntry, error)
+	UpdateTimeEntry(int64, int64, CreateTimeEntry) (TimeEntry, error)
+	DeleteTimeEntry(int64, int64) error
+	StartTimer(int64, int64) (TimeEntry, error)
+	StopTimer(int64, int64) (TimeEntry, error)
+	Timesheet(time.Time, time.Time, TicketSlice) (*Timesheet, error)
+	CreateChild(int64, CreateTicket) (Ticket, error)
+	CreateChildren(int64, ...CreateTicket) (TicketSlice, error)
+	Children(int64) (TicketSlice, error)
+	Associations(int64) (TicketAssociations, error)
+	Activities(int64) (ActivitySlice, error)
+	Timeline(int64) ([]TimelineEntry, error)
 }
 
 type ticketManager struct {
@@ -57,16 +85,16 @@ type Ticket struct {
 	IsEscalated            bool                   `bson:"is_escalated" json:"is_escalated"`
 	Name                   string                 `bson:"name" json:"name"`
 	Phone                  string                 `bson:"phone" json:"phone"`
-	Priority               int                    `bson:"priority" json:"priority"`
+	Priority               Priority               `bson:"priority" json:"priority"`
 	Category               string                 `bson:"category" json:"category"`
 	SubCategory            []string               `bson:"sub_category" json:"sub_category"`
 	ItemCategory           []string               `bson:"item_category" json:"item_category"`
 	ReplyCCEmails          []string               `bson:"reply_cc_emails" json:"reply_cc_emails"`
 	RequesterID            int64                  `bson:"requester_id" json:"requester_id"`
 	ResponderID            int64                  `bson:"responder_id" json:"responder_id"`
-	Source                 int                    `bson:"source" json:"source"`
+	Source                 Source                 `bson:"source" json:"source"`
 	Spam                   bool                   `bson:"spam" json:"spam"`
-	Status                 int                    `bson:"status" json:"status"`
+	Status                 Status                 `bson:"status" json:"status"`
 	Subject                string                 `bson:"subjecte" json:"subject"`
 	Tags                   []string               `bson:"tags" json:"tags"`
 	ToEmails               []string               `bson:"to_emails" json:"to_emails"`
@@ -76,6 +104,35 @@ type Ticket struct {
 	Urgency                string                 `bson:"urgency" json:"urgency"`
 	Impact                 int64                  `bson:"impact" json:"impact"`
 	Conversations          []Conversation         `bson:"-" json:"conversations"`
+	// Problem and the change fields are only populated when requested
+	// through ViewWith.
+	Problem                 *Problem            `bson:"-" json:"problem"`
+	ChangeInitiatingTicket  *Change             `bson:"-" json:"change_initiating_ticket"`
+	ChangeInitiatedByTicket *Change             `bson:"-" json:"change_initiated_by_ticket"`
+	AssociationType         AssociationType     `bson:"association_type" json:"association_type"`
+	RelatedTickets          *TicketAssociations `bson:"-" json:"related_tickets"`
+}
+
+// TicketAssociations lists the tickets associated with a ticket. It is
+// populated when requested with TicketIncludeRelatedTickets.
+type TicketAssociations struct {
+	ParentID   int64   `json:"parent_id"`
+	ChildIDs   []int64 `json:"child_ids"`
+	TrackerID  int64   `json:"tracker_id"`
+	RelatedIDs []int64 `json:"related_ids"`
+}
+
+type AssociationType int
+
+const (
+	AssociationTypeParent AssociationType = 1 + iota
+	AssociationTypeChild
+	AssociationTypeTracker
+	AssociationTypeRelated
+)
+
+func (a AssociationType) Value() int {
+	return int(a)
 }
 
 type RespTickets struct {
@@ -87,34 +144,55 @@ type RespTicket struct {
 }
 
 type CreateTicket struct {
-	Name               string                 `json:"name,omitempty"`
-	RequesterID        int                    `json:"requester_id,omitempty"`
-	Email              string                 `json:"email,omitempty"`
-	Phone              string                 `json:"phone,omitempty"`
-	Subject            string                 `json:"subject,omitempty"`
-	Type               string                 `json:"type,omitempty"`
-	Status             int                    `json:"status,omitempty"`
-	Priority           int                    `json:"priority,omitempty"`
-	Description        string                 `json:"description,omitempty"`
-	ResponderID        int                    `json:"responder_id,omitempty"`
-	Attachments        []interface{}          `json:"attachments,omitempty"`
-	CCEmails           []string               `json:"cc_emails,omitempty"`
-	CustomFields       map[string]interface{} `json:"custom_fields,omitempty"`
-	DueBy              *time.Time             `json:"due_by,omitempty"`
-	EmailConfigID      int                    `json:"email_config_id,omitempty"`
-	FirstResponseDueBy *time.Time             `json:"fr_due_by,omitempty"`
-	GroupID            int                    `json:"group_id,omitempty"`
-	Source             int                    `json:"source,omitempty"`
-	Tags               []string               `json:"tags,omitempty"`
-	DepartmentID       int64                  `json:"department_id,omitempty"`
-	Category           string                 `json:"category,omitempty"`
-	SubCategory        []string               `json:"sub_category,omitempty"`
-	ItemCategory       []string               `json:"item_category,omitempty"`
-	Assets             string                 `json:"assets,omitempty"`
-	Urgency            string                 `json:"urgency,omitempty"`
-	Impact             int64                  `json:"impact,omitempty"`
+	Name                    string                 `json:"name,omitempty"`
+	RequesterID             int64                  `json:"requester_id,omitempty"`
+	Email                   string                 `json:"email,omitempty"`
+	Phone                   string                 `json:"phone,omitempty"`
+	Subject                 string                 `json:"subject,omitempty"`
+	Type                    string                 `json:"type,omitempty"`
+	Status                  Status                 `json:"status,omitempty"`
+	Priority                Priority               `json:"priority,omitempty"`
+	Description             string                 `json:"description,omitempty"`
+	ResponderID             int64                  `json:"responder_id,omitempty"`
+	Attachments             []interface{}          `json:"attachments,omitempty"`
+	CCEmails                []string               `json:"cc_emails,omitempty"`
+	CustomFields            map[string]interface{} `json:"custom_fields,omitempty"`
+	DueBy                   *time.Time             `json:"due_by,omitempty"`
+	EmailConfigID           int64                  `json:"email_config_id,omitempty"`
+	FirstResponseDueBy      *time.Time             `json:"fr_due_by,omitempty"`
+	GroupID                 int64                  `json:"group_id,omitempty"`
+	Source                  Source                 `json:"source,omitempty"`
+	Tags                    []string               `json:"tags,omitempty"`
+	DepartmentID            int64                  `json:"department_id,omitempty"`
+	Category                string                 `json:"category,omitempty"`
+	SubCategory             []string               `json:"sub_category,omitempty"`
+	ItemCategory            []string               `json:"item_category,omitempty"`
+	Assets                  string                 `json:"assets,omitempty"`
+	Urgency                 string                 `json:"urgency,omitempty"`
+	Impact                  int64                  `json:"impact,omitempty"`
+	Problem                 *AssociatedItem        `json:"problem,omitempty"`
+	ChangeInitiatingTicket  *AssociatedItem        `json:"change_initiating_ticket,omitempty"`
+	ChangeInitiatedByTicket *AssociatedItem        `json:"change_initiated_by_ticket,omitempty"`
 }
 
+// AssociatedItem references a problem or change when associating it with a ticket.
+type AssociatedItem struct {
+	ID int64 `json:"id"`
+}
+
+// TicketInclude names related data embedded in a ticket by ViewWith.
+type TicketInclude string
+
+const (
+	TicketIncludeConversations  TicketInclude = "conversations"
+	TicketIncludeRequester      TicketInclude = "requester"
+	TicketIncludeStats          TicketInclude = "stats"
+	TicketIncludeAssets         TicketInclude = "assets"
+	TicketIncludeProblem        TicketInclude = "problem"
+	TicketIncludeChange         TicketInclude = "change"
+	TicketIncludeRelatedTickets TicketInclude = "related_tickets"
+)
+
 type Conversation struct {
 	mgm.DefaultModel `bson:",inline" json:"-"`
 	Attachments      []interface{} `json:"attachments"`
@@ -144,7 +222,7 @@ type CreateConversation struct {
 	Body        string        `json:"body,omitempty"`
 	FromEmail   string        `json:"from_email,omitempty"`
 	Attachments []interface{} `json:"attachments,omitempty"`
-	UserID      int           `json:"user_id,omitempty"`
+	UserID      int64         `json:"user_id,omitempty"`
 	CCEmails    []string      `json:"cc_emails,omitempty"`
 	BCCEmails   []string      `json:"bcc_emails,omitempty"`
 }
@@ -245,10 +323,14 @@ func (manager ticketManager) All() (TicketResults, error) {
 	}, nil
 }
 
-func (manager ticketManager) UpdatedSinceAll(timeString string) (TicketResults, error) {
+// UpdatedSinceAll returns the first page of tickets updated since the time.
+// Further pages are fetched with Next.
+func (manager ticketManager) UpdatedSinceAll(since time.Time) (TicketResults, error) {
+	query := querybuilder.BuildQuery()
+	query.UpdatedSince(since)
 	resp := RespTickets{}
 	output := TicketSlice{}
-	headers, err := manager.client.get(endpoints.tickets.updatedSinceAll(timeString), &resp)
+	headers, err := manager.client.get(endpoints.tickets.search(query.URLSafe()), &resp)
 	if err != nil {
 		return TicketResults{}, err
 	}
@@ -284,6 +366,47 @@ func (manager ticketManager) View(id int64) (Ticket, error) {
 	return output.Ticket, nil
 }
 
+// ViewWith returns the ticket with the requested related data embedded.
+func (manager ticketManager) ViewWith(id int64, includes ...TicketInclude) (Ticket, error) {
+	if len(includes) == 0 {
+		return manager.View(id)
+	}
+	names := make([]string, 0, len(includes))
+	for _, include := range includes {
+		names = append(names, string(include))
+	}
+	output := RespTicket{}
+	_, err := manager.client.get(endpoints.tickets.include(id, strings.Join(names, ",")), &output)
+	if err != nil {
+		return Ticket{}, err
+	}
+
+	return output.Ticket, nil
+}
+
+func (manager ticketManager) Update(id int64, ticket CreateTicket) (Ticket, error) {
+	output := RespTicket{}
+	jsonb, err := json.Marshal(ticket)
+	if err != nil {
+		return Ticket{}, err
+	}
+	err = manager.client.put(endpoints.tickets.update(id), jsonb, &output, http.StatusOK)
+	if err != nil {
+		return Ticket{}, err
+	}
+	return output.Ticket, nil
+}
+
+// AssociateProblem links the ticket to a problem record.
+func (manager ticketManager) AssociateProblem(id int64, problemID int64) (Ticket, error) {
+	return manager.Update(id, CreateTicket{Problem: &AssociatedItem{problemID}})
+}
+
+// AssociateChange links the ticket to a change raised to resolve it.
+func (manager ticketManager) AssociateChange(id int64, changeID int64) (Ticket, error) {
+	return manager.Update(id, CreateTicket{ChangeInitiatedByTicket: &AssociatedItem{changeID}})
+}
+
 func (manager ticketManager) Conversations(id int64) (ConversationSlice, error) {
 	resp := RespConversations{}
 	output := ConversationSlice{}
@@ -295,6 +418,35 @@ func (manager ticketManager) Conversations(id int64) (ConversationSlice, error)
 	return output, nil
 }
 
+func (manager ticketManager) Activities(id int64) (ActivitySlice, error) {
+	resp := RespActivities{}
+	output := ActivitySlice{}
+	_, err := manager.client.get(endpoints.tickets.activities(id), &resp)
+	if err != nil {
+		return ActivitySlice{}, err
+	}
+	output = append(output, resp.Activities...)
+	return output, nil
+}
+
+// Timeline fetches the activities and conversations of the ticket and merges
+// them with BuildTimeline.
+func (manager ticketManager) Timeline(id int64) ([]TimelineEntry, error) {
+	activities, err := manager.Activities(id)
+	if err != nil {
+		return nil, err
+	}
+	conversations, err := manager.Conversations(id)
+	if err != nil {
+		return nil, err
+	}
+	return BuildTimeline(activities, conversations), nil
+}
+
+func (manager ticketManager) AddNote(id int64, note CreateConversation) (Conversation, error) {
+	return manager.client.postNote(endpoints.tickets.notes(id), note)
+}
+
 func (manager ticketManager) Reply(id int64, reply CreateConversation) (Conversation, error) {
 	output := RespConversation{}
 	jsonb, err := json.Marshal(reply)
@@ -308,6 +460,178 @@ func (manager ticketManager) Reply(id int64, reply CreateConversation) (Conversa
 	return output.Conversation, nil
 }
 
+func (manager ticketManager) Tasks(id int64) (TaskSlice, error) {
+	return manager.client.getTasks(endpoints.tickets.tasks(id))
+}
+
+func (manager ticketManager) ViewTask(id, taskID int64) (Task, error) {
+	return manager.client.getTask(endpoints.tickets.task(id, taskID))
+}
+
+func (manager ticketManager) CreateTask(id int64, task CreateTask) (Task, error) {
+	return manager.client.postTask(endpoints.tickets.tasks(id), task)
+}
+
+func (manager ticketManager) UpdateTask(id, taskID int64, task CreateTask) (Task, error) {
+	return manager.client.putTask(endpoints.tickets.task(id, taskID), task)
+}
+
+func (manager ticketManager) DeleteTask(id, taskID int64) error {
+	return manager.client.delete(endpoints.tickets.task(id, taskID), http.StatusNoContent)
+}
+
+// TasksCompleted reports whether every task on the ticket is completed, so the
+// ticket can be resolved. A ticket without tasks counts as completed.
+func (manager ticketManager) TasksCompleted(id int64) (bool, error) {
+	tasks, err := manager.Tasks(id)
+	if err != nil {
+		return false, err
+	}
+	return tasks.Completed(), nil
+}
+
+func (manager ticketManager) TimeEntries(id int64) (TimeEntrySlice, error) {
+	return manager.client.getTimeEntries(endpoints.tickets.timeEntries(id))
+}
+
+func (manager ticketManager) ViewTimeEntry(id, entryID int64) (TimeEntry, error) {
+	return manager.client.getTimeEntry(endpoints.tickets.timeEntry(id, entryID))
+}
+
+func (manager ticketManager) CreateTimeEntry(id int64, entry CreateTimeEntry) (TimeEntry, error) {
+	return manager.client.postTimeEntry(endpoints.tickets.timeEntries(id), entry)
+}
+
+func (manager ticketManager) UpdateTimeEntry(id, entryID int64, entry CreateTimeEntry) (TimeEntry, error) {
+	return manager.client.putTimeEntry(endpoints.tickets.timeEntry(id, entryID), entry)
+}
+
+func (manager ticketManager) DeleteTimeEntry(id, entryID int64) error {
+	return manager.client.delete(endpoints.tickets.timeEntry(id, entryID), http.StatusNoContent)
+}
+
+func (manager ticketManager) StartTimer(id, entryID int64) (TimeEntry, error) {
+	return manager.client.setTimer(endpoints.tickets.timeEntry(id, entryID), true)
+}
+
+func (manager ticketManager) StopTimer(id, entryID int64) (TimeEntry, error) {
+	return manager.client.setTimer(endpoints.tickets.timeEntry(id, entryID), false)
+}
+
+// Timesheet fetches the time entries of the tickets and aggregates those
+// executed within [from, to).
+func (manager ticketManager) Timesheet(from, to time.Time, tickets TicketSlice) (*Timesheet, error) {
+	sheet := NewTimesheet(from, to)
+	for _, ticket := range tickets {
+		entries, err := manager.TimeEntries(ticket.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := sheet.Add(ticket, entries); err != nil {
+			return nil, err
+		}
+	}
+	return sheet, nil
+}
+
+// CreateChild creates a ticket under the parent ticket.
+func (manager ticketManager) CreateChild(parentID int64, ticket CreateTicket) (Ticket, error) {
+	output := RespTicket{}
+	jsonb, err := json.Marshal(ticket)
+	if err != nil {
+		return Ticket{}, err
+	}
+	err = manager.client.postJSON(endpoints.tickets.createChild(parentID), jsonb, &output, http.StatusCreated)
+	if err != nil {
+		return Ticket{}, err
+	}
+	return output.Ticket, nil
+}
+
+// CreateChildren creates a child ticket for each payload, e.g. one per team of
+// an onboarding request. The children created before an error are returned.
+func (manager ticketManager) CreateChildren(parentID int64, tickets ...CreateTicket) (TicketSlice, error) {
+	output := TicketSlice{}
+	for _, ticket := range tickets {
+		child, err := manager.CreateChild(parentID, ticket)
+		if err != nil {
+			return output, err
+		}
+		output = append(output, child)
+	}
+	return output, nil
+}
+
+// Children fetches the child tickets of the parent ticket.
+func (manager ticketManager) Children(parentID int64) (TicketSlice, error) {
+	associations, err := manager.Associations(parentID)
+	if err != nil {
+		return TicketSlice{}, err
+	}
+	output := TicketSlice{}
+	for _, id := range associations.ChildIDs {
+		child, err := manager.View(id)
+		if err != nil {
+			return TicketSlice{}, err
+		}
+		output = append(output, child)
+	}
+	return output, nil
+}
+
+func (manager ticketManager) Associations(id int64) (TicketAssociations, error) {
+	ticket, err := manager.ViewWith(id, TicketIncludeRelatedTickets)
+	if err != nil {
+		return TicketAssociations{}, err
+	}
+	if ticket.RelatedTickets == nil {
+		return TicketAssociations{}, nil
+	}
+	return *ticket.RelatedTickets, nil
+}
+
+// Forward sends the ticket to the given addresses, e.g. a vendor, with body
+// added above the ticket details.
+func (manager ticketManager) Forward(id int64, to []string, body string) (Conversation, error) {
+	output := RespConversation{}
+	jsonb, err := json.Marshal(struct {
+		Body     string   `json:"body,omitempty"`
+		ToEmails []string `json:"to_emails"`
+	}{body, to})
+	if err != nil {
+		return Conversation{}, err
+	}
+	err = manager.client.postJSON(endpoints.tickets.forward(id), jsonb, &output, http.StatusCreated)
+	if err != nil {
+		return Conversation{}, err
+	}
+	return output.Conversation, nil
+}
+
+// Merge folds the secondary tickets into the primary ticket. A non-empty note
+// is added to the primary ticket as a private note.
+func (manager ticketManager) Merge(primaryID int64, secondaryIDs []int64, note string) error {
+	payload := struct {
+		PrimaryID     int64       `json:"primary_id"`
+		TicketIDs     []int64     `json:"ticket_ids"`
+		NoteInPrimary interface{} `json:"note_in_primary,omitempty"`
+	}{
+		PrimaryID: primaryID,
+		TicketIDs: secondaryIDs,
+	}
+	if note != "" {
+		payload.NoteInPrimary = struct {
+			Body    string `json:"body"`
+			Private bool   `json:"private"`
+		}{note, true}
+	}
+	jsonb, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return manager.client.put(endpoints.tickets.merge, jsonb, nil, http.StatusNoContent)
+}
+
 func (manager ticketManager) Search(query querybuilder.Query) (TicketResults, error) {
 	resp := RespTickets{}
 	output := TicketSlice{}
@@ -0,0 +1,162 @@
+package freshdesk
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// recordedRequest is a request received by a recording test client.
+type recordedRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Body   string
+}
+
+// recordingClient returns a client recording every request into requests and
+// answering it with the handler. Requests reach the handler as the client
+// built them, so bodiless requests have a nil Body.
+func recordingClient(requests *[]recordedRequest, handler http.HandlerFunc) ApiClient {
+	return testClient(func(w http.ResponseWriter, r *http.Request) {
+		body := []byte{}
+		if r.Body != nil {
+			body, _ = ioutil.ReadAll(r.Body)
+		}
+		*requests = append(*requests, recordedRequest{r.Method, r.URL.Path, r.URL.RawQuery, string(body)})
+		handler(w, r)
+	})
+}
+
+func TestCreateChildren(t *testing.T) {
+	requests := []recordedRequest{}
+	client := recordingClient(&requests, func(w http.ResponseWriter, r *http.Request) {
+		if len(requests) == 3 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"description": "Validation failed"}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"ticket": {"id": %d}}`, 100+len(requests))
+	})
+	children, err := client.Tickets.CreateChildren(42,
+		CreateTicket{Subject: "Laptop", GroupID: 3},
+		CreateTicket{Subject: "Badge", RequesterID: 9},
+		CreateTicket{Subject: "Desk"},
+		CreateTicket{Subject: "Never sent"},
+	)
+	if err == nil {
+		t.Error("CreateChildren() ignored the failed child")
+	}
+	if got := []int64{children[0].ID, children[1].ID}; len(children) != 2 || !reflect.DeepEqual(got, []int64{101, 102}) {
+		t.Errorf("CreateChildren() = %+v, want the two children created before the error", children)
+	}
+	want := []recordedRequest{
+		{http.MethodPost, "/api/v2/tickets/42/create_child_ticket", "", `{"subject":"Laptop","group_id":3}`},
+		{http.MethodPost, "/api/v2/tickets/42/create_child_ticket", "", `{"requester_id":9,"subject":"Badge"}`},
+		{http.MethodPost, "/api/v2/tickets/42/create_child_ticket", "", `{"subject":"Desk"}`},
+	}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("sent %+v, want %+v", requests, want)
+	}
+}
+
+func TestTicketChildren(t *testing.T) {
+	requests := []recordedRequest{}
+	client := recordingClient(&requests, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/tickets/42":
+			w.Write([]byte(`{"ticket": {"id": 42, "related_tickets": {"parent_id": 7, "child_ids": [43, 44], "related_ids": [50]}}}`))
+		default:
+			var id int64
+			fmt.Sscanf(r.URL.Path, "/api/v2/tickets/%d", &id)
+			fmt.Fprintf(w, `{"ticket": {"id": %d, "association_type": 2}}`, id)
+		}
+	})
+
+	associations, err := client.Tickets.Associations(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (TicketAssociations{ParentID: 7, ChildIDs: []int64{43, 44}, RelatedIDs: []int64{50}}); !reflect.DeepEqual(associations, want) {
+		t.Errorf("Associations() = %+v, want %+v", associations, want)
+	}
+	if requests[0].Query != "include=related_tickets" {
+		t.Errorf("Associations() query = %q", requests[0].Query)
+	}
+
+	children, err := client.Tickets.Children(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(children) != 2 || children[0].ID != 43 || children[1].ID != 44 || children[0].AssociationType != AssociationTypeChild {
+		t.Errorf("Children() = %+v", children)
+	}
+
+	client = testClient(respond(http.StatusOK, `{"ticket": {"id": 42}}`))
+	if associations, err := client.Tickets.Associations(42); err != nil || !reflect.DeepEqual(associations, TicketAssociations{}) {
+		t.Errorf("Associations() without related tickets = %+v, %v", associations, err)
+	}
+}
+
+func TestTicketForward(t *testing.T) {
+	tests := []struct {
+		name string
+		to   []string
+		body string
+		want string
+	}{
+		{"with body", []string{"vendor@example.com", "noc@example.com"}, "Please check the uplink.",
+			`{"body":"Please check the uplink.","to_emails":["vendor@example.com","noc@example.com"]}`},
+		{"without body", []string{"vendor@example.com"}, "", `{"to_emails":["vendor@example.com"]}`},
+	}
+	for _, test := range tests {
+		requests := []recordedRequest{}
+		client := recordingClient(&requests, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"conversation": {"id": 9}}`))
+		})
+		conversation, err := client.Tickets.Forward(42, test.to, test.body)
+		if err != nil || conversation.ID != 9 {
+			t.Errorf("%s: Forward() = %+v, %v", test.name, conversation, err)
+			continue
+		}
+		want := []recordedRequest{{http.MethodPost, "/api/v2/tickets/42/forward", "", test.want}}
+		if !reflect.DeepEqual(requests, want) {
+			t.Errorf("%s: sent %+v, want %+v", test.name, requests, want)
+		}
+	}
+}
+
+func TestTicketMerge(t *testing.T) {
+	tests := []struct {
+		name         string
+		secondaryIDs []int64
+		note         string
+		want         string
+	}{
+		{"with note", []int64{43, 44}, "Duplicates of the outage", `{"primary_id":42,"ticket_ids":[43,44],"note_in_primary":{"body":"Duplicates of the outage","private":true}}`},
+		{"without note", []int64{43}, "", `{"primary_id":42,"ticket_ids":[43]}`},
+	}
+	for _, test := range tests {
+		requests := []recordedRequest{}
+		client := recordingClient(&requests, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+		if err := client.Tickets.Merge(42, test.secondaryIDs, test.note); err != nil {
+			t.Errorf("%s: Merge() error = %v", test.name, err)
+			continue
+		}
+		want := []recordedRequest{{http.MethodPut, "/api/v2/tickets/merge", "", test.want}}
+		if !reflect.DeepEqual(requests, want) {
+			t.Errorf("%s: sent %+v, want %+v", test.name, requests, want)
+		}
+	}
+
+	client := testClient(respond(http.StatusBadRequest, `{"description": "Validation failed"}`))
+	if err := client.Tickets.Merge(42, []int64{42}, ""); err == nil {
+		t.Error("Merge() ignored the rejected request")
+	}
+}
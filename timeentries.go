@@ -0,0 +1,144 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// TimeEntry records time an agent spent on a ticket, change, problem or release.
+// TimeSpent is formatted as "hh:mm".
+type TimeEntry struct {
+	ID           int64                  `bson:"id" json:"id"`
+	AgentID      int64                  `bson:"agent_id" json:"agent_id"`
+	TaskID       int64                  `bson:"task_id" json:"task_id"`
+	StartTime    *time.Time             `bson:"start_time" json:"start_time"`
+	TimeSpent    string                 `bson:"time_spent" json:"time_spent"`
+	ExecutedAt   *time.Time             `bson:"executed_at" json:"executed_at"`
+	TimerRunning bool                   `bson:"timer_running" json:"timer_running"`
+	Billable     bool                   `bson:"billable" json:"billable"`
+	Note         string                 `bson:"note" json:"note"`
+	CustomFields map[string]interface{} `bson:"custom_fields" json:"custom_fields"`
+	CreatedAt    *time.Time             `bson:"created_at" json:"created_at"`
+	UpdatedAt    *time.Time             `bson:"updated_at" json:"updated_at"`
+}
+
+// CreateTimeEntry is the payload to create or update a time entry. Nil fields
+// are left out; Freshservice defaults Billable to true, so set it to Bool(false)
+// to record non-billable time.
+type CreateTimeEntry struct {
+	AgentID      int64                  `json:"agent_id,omitempty"`
+	TaskID       int64                  `json:"task_id,omitempty"`
+	StartTime    *time.Time             `json:"start_time,omitempty"`
+	TimeSpent    string                 `json:"time_spent,omitempty"`
+	ExecutedAt   *time.Time             `json:"executed_at,omitempty"`
+	TimerRunning *bool                  `json:"timer_running,omitempty"`
+	Billable     *bool                  `json:"billable,omitempty"`
+	Note         string                 `json:"note,omitempty"`
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+}
+
+type RespTimeEntries struct {
+	TimeEntries []TimeEntry `json:"time_entries,omitempty"`
+}
+
+type RespTimeEntry struct {
+	TimeEntry TimeEntry `json:"time_entry,omitempty"`
+}
+
+type TimeEntrySlice []TimeEntry
+
+func (s TimeEntrySlice) Len() int { return len(s) }
+
+func (s TimeEntrySlice) Less(i, j int) bool { return s[i].ID < s[j].ID }
+
+func (s TimeEntrySlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s TimeEntrySlice) Print() {
+	for _, entry := range s {
+		fmt.Println(entry.TimeSpent, entry.Note)
+	}
+}
+
+// Duration parses TimeSpent.
+func (t TimeEntry) Duration() (time.Duration, error) {
+	var hours, minutes int
+	if t.TimeSpent == "" {
+		return 0, nil
+	}
+	if _, err := fmt.Sscanf(t.TimeSpent, "%d:%d", &hours, &minutes); err != nil {
+		return 0, fmt.Errorf("invalid time spent %q", t.TimeSpent)
+	}
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute, nil
+}
+
+// FormatTimeSpent formats a duration as the "hh:mm" used by TimeSpent.
+func FormatTimeSpent(d time.Duration) string {
+	minutes := int64(d / time.Minute)
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+func (c *ApiClient) getTimeEntries(path string) (TimeEntrySlice, error) {
+	resp := RespTimeEntries{}
+	output := TimeEntrySlice{}
+	headers, err := c.get(path, &resp)
+	if err != nil {
+		return TimeEntrySlice{}, err
+	}
+	output = append(output, resp.TimeEntries...)
+
+	for {
+		nextLink := c.getNextLink(headers)
+		if nextLink == "" {
+			break
+		}
+		nextResp := RespTimeEntries{}
+		headers, err = c.get(nextLink, &nextResp)
+		if err != nil {
+			return TimeEntrySlice{}, err
+		}
+		output = append(output, nextResp.TimeEntries...)
+	}
+	return output, nil
+}
+
+func (c *ApiClient) getTimeEntry(path string) (TimeEntry, error) {
+	output := RespTimeEntry{}
+	_, err := c.get(path, &output)
+	if err != nil {
+		return TimeEntry{}, err
+	}
+	return output.TimeEntry, nil
+}
+
+func (c *ApiClient) postTimeEntry(path string, entry CreateTimeEntry) (TimeEntry, error) {
+	output := RespTimeEntry{}
+	jsonb, err := json.Marshal(entry)
+	if err != nil {
+		return TimeEntry{}, err
+	}
+	err = c.postJSON(path, jsonb, &output, http.StatusCreated)
+	if err != nil {
+		return TimeEntry{}, err
+	}
+	return output.TimeEntry, nil
+}
+
+func (c *ApiClient) putTimeEntry(path string, entry CreateTimeEntry) (TimeEntry, error) {
+	output := RespTimeEntry{}
+	jsonb, err := json.Marshal(entry)
+	if err != nil {
+		return TimeEntry{}, err
+	}
+	err = c.put(path, jsonb, &output, http.StatusOK)
+	if err != nil {
+		return TimeEntry{}, err
+	}
+	return output.TimeEntry, nil
+}
+
+// setTimer starts or stops the timer of a time entry.
+func (c *ApiClient) setTimer(path string, running bool) (TimeEntry, error) {
+	return c.putTimeEntry(path, CreateTimeEntry{TimerRunning: Bool(running)})
+}
@@ -0,0 +1,70 @@
+package freshdesk
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCreateTimeEntryJSON(t *testing.T) {
+	executed := time.Date(2020, 5, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		entry CreateTimeEntry
+		want  string
+	}{
+		{"empty", CreateTimeEntry{}, `{}`},
+		{"non-billable", CreateTimeEntry{TimeSpent: "01:30", Billable: Bool(false)}, `{"time_spent":"01:30","billable":false}`},
+		{"billable", CreateTimeEntry{AgentID: 7, Billable: Bool(true), ExecutedAt: &executed}, `{"agent_id":7,"executed_at":"2020-05-01T09:00:00Z","billable":true}`},
+		{"timer stopped", CreateTimeEntry{TimerRunning: Bool(false)}, `{"timer_running":false}`},
+	}
+	for _, test := range tests {
+		jsonb, err := json.Marshal(test.entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(jsonb) != test.want {
+			t.Errorf("%s: %s, want %s", test.name, jsonb, test.want)
+		}
+	}
+}
+
+func TestTimeEntryDuration(t *testing.T) {
+	tests := []struct {
+		spent   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"00:00", 0, false},
+		{"01:30", 90 * time.Minute, false},
+		{"12:05", 12*time.Hour + 5*time.Minute, false},
+		{"an hour", 0, true},
+	}
+	for _, test := range tests {
+		got, err := TimeEntry{TimeSpent: test.spent}.Duration()
+		if got != test.want || (err != nil) != test.wantErr {
+			t.Errorf("Duration(%q) = %v, %v", test.spent, got, err)
+		}
+	}
+	if got := FormatTimeSpent(125 * time.Minute); got != "02:05" {
+		t.Errorf("FormatTimeSpent() = %q", got)
+	}
+}
+
+func TestStopTimer(t *testing.T) {
+	body := ""
+	client := testClient(func(w http.ResponseWriter, r *http.Request) {
+		jsonb, _ := ioutil.ReadAll(r.Body)
+		body = string(jsonb)
+		w.Write([]byte(`{"time_entry": {"id": 3}}`))
+	})
+	if _, err := client.Tickets.StopTimer(1, 3); err != nil {
+		t.Fatal(err)
+	}
+	if body != `{"timer_running":false}` {
+		t.Errorf("StopTimer() sent %s", body)
+	}
+}
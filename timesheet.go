@@ -0,0 +1,172 @@
+package freshdesk
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Timesheet aggregates ticket time entries executed within [From, To), one row
+// per agent, department and ticket.
+type Timesheet struct {
+	From time.Time
+	To   time.Time
+	// Agents and Departments are optional and only used to add names to the CSV.
+	Agents      AgentSlice
+	Departments DepartmentSlice
+	rows        map[timesheetKey]*TimesheetRow
+}
+
+type TimesheetRow struct {
+	AgentID      int64
+	DepartmentID int64
+	TicketID     int64
+	Entries      int
+	Billable     time.Duration
+	NonBillable  time.Duration
+}
+
+type timesheetKey struct {
+	agentID, departmentID, ticketID int64
+}
+
+// Total returns the billable and non-billable time of the row.
+func (row TimesheetRow) Total() time.Duration {
+	return row.Billable + row.NonBillable
+}
+
+func NewTimesheet(from, to time.Time) *Timesheet {
+	return &Timesheet{
+		From: from,
+		To:   to,
+		rows: map[timesheetKey]*TimesheetRow{},
+	}
+}
+
+// Add records the entries of the ticket that fall within the date range. It
+// replaces whatever was recorded for the ticket before, so adding a ticket
+// again with its current entries does not count them twice. On error the
+// timesheet is left unchanged.
+func (sheet *Timesheet) Add(ticket Ticket, entries TimeEntrySlice) error {
+	rows := map[timesheetKey]*TimesheetRow{}
+	for _, entry := range entries {
+		executed := entry.ExecutedAt
+		if executed == nil {
+			executed = entry.CreatedAt
+		}
+		if executed == nil || executed.Before(sheet.From) || !executed.Before(sheet.To) {
+			continue
+		}
+		spent, err := entry.Duration()
+		if err != nil {
+			return fmt.Errorf("ticket %d time entry %d: %v", ticket.ID, entry.ID, err)
+		}
+		key := timesheetKey{entry.AgentID, ticket.DepartmentID, ticket.ID}
+		row, ok := rows[key]
+		if !ok {
+			row = &TimesheetRow{AgentID: key.agentID, DepartmentID: key.departmentID, TicketID: key.ticketID}
+			rows[key] = row
+		}
+		row.Entries++
+		if entry.Billable {
+			row.Billable += spent
+		} else {
+			row.NonBillable += spent
+		}
+	}
+	for key := range sheet.rows {
+		if key.ticketID == ticket.ID {
+			delete(sheet.rows, key)
+		}
+	}
+	for key, row := range rows {
+		sheet.rows[key] = row
+	}
+	return nil
+}
+
+// Rows returns the rows ordered by agent, department and ticket.
+func (sheet *Timesheet) Rows() []TimesheetRow {
+	rows := make([]TimesheetRow, 0, len(sheet.rows))
+	for _, row := range sheet.rows {
+		rows = append(rows, *row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].AgentID != rows[j].AgentID {
+			return rows[i].AgentID < rows[j].AgentID
+		}
+		if rows[i].DepartmentID != rows[j].DepartmentID {
+			return rows[i].DepartmentID < rows[j].DepartmentID
+		}
+		return rows[i].TicketID < rows[j].TicketID
+	})
+	return rows
+}
+
+// ByAgent returns the total time per agent.
+func (sheet *Timesheet) ByAgent() map[int64]time.Duration {
+	totals := map[int64]time.Duration{}
+	for key, row := range sheet.rows {
+		totals[key.agentID] += row.Total()
+	}
+	return totals
+}
+
+// ByDepartment returns the total time per department.
+func (sheet *Timesheet) ByDepartment() map[int64]time.Duration {
+	totals := map[int64]time.Duration{}
+	for key, row := range sheet.rows {
+		totals[key.departmentID] += row.Total()
+	}
+	return totals
+}
+
+// ByTicket returns the total time per ticket.
+func (sheet *Timesheet) ByTicket() map[int64]time.Duration {
+	totals := map[int64]time.Duration{}
+	for key, row := range sheet.rows {
+		totals[key.ticketID] += row.Total()
+	}
+	return totals
+}
+
+// WriteCSV writes the rows with a header line. Durations are written in hours
+// with two decimals.
+func (sheet *Timesheet) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{
+		"agent_id", "agent", "department_id", "department", "ticket_id",
+		"entries", "billable_hours", "non_billable_hours", "total_hours",
+	})
+	for _, row := range sheet.Rows() {
+		agent, _ := sheet.Agents.Find(row.AgentID)
+		department := ""
+		for _, d := range sheet.Departments {
+			if d.ID == row.DepartmentID {
+				department = d.Name
+				break
+			}
+		}
+		writer.Write([]string{
+			strconv.FormatInt(row.AgentID, 10),
+			strings.TrimSpace(agent.FirstName + " " + agent.LastName),
+			strconv.FormatInt(row.DepartmentID, 10),
+			department,
+			strconv.FormatInt(row.TicketID, 10),
+			strconv.Itoa(row.Entries),
+			formatHours(row.Billable),
+			formatHours(row.NonBillable),
+			formatHours(row.Total()),
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatHours(d time.Duration) string {
+	return strconv.FormatFloat(d.Hours(), 'f', 2, 64)
+}
@@ -0,0 +1,106 @@
+package freshdesk
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var sheetStart = time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+
+func timeEntry(id, agentID int64, day int, spent string, billable bool) TimeEntry {
+	executed := sheetStart.AddDate(0, 0, day)
+	return TimeEntry{ID: id, AgentID: agentID, ExecutedAt: &executed, TimeSpent: spent, Billable: billable}
+}
+
+func testTimesheet(t *testing.T) *Timesheet {
+	t.Helper()
+	sheet := NewTimesheet(sheetStart, sheetStart.AddDate(0, 1, 0))
+	sheet.Agents = AgentSlice{{ID: 7, FirstName: "Jane", LastName: "Doe"}}
+	sheet.Departments = DepartmentSlice{{ID: 3, Name: "Finance, Legal"}}
+	created := sheetStart.AddDate(0, 0, 2)
+	if err := sheet.Add(Ticket{ID: 100, DepartmentID: 3}, TimeEntrySlice{
+		timeEntry(1, 7, 0, "01:30", true),
+		timeEntry(2, 7, 1, "00:45", false),
+		timeEntry(3, 8, 1, "02:00", true),
+		{ID: 4, AgentID: 8, CreatedAt: &created, TimeSpent: "00:15"},
+		timeEntry(5, 7, -1, "05:00", true),
+		timeEntry(6, 7, 31, "05:00", true),
+		{ID: 7, AgentID: 7, TimeSpent: "05:00"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := sheet.Add(Ticket{ID: 101}, TimeEntrySlice{timeEntry(8, 7, 3, "00:30", true)}); err != nil {
+		t.Fatal(err)
+	}
+	return sheet
+}
+
+func TestTimesheetRows(t *testing.T) {
+	sheet := testTimesheet(t)
+	want := []TimesheetRow{
+		{AgentID: 7, DepartmentID: 0, TicketID: 101, Entries: 1, Billable: 30 * time.Minute},
+		{AgentID: 7, DepartmentID: 3, TicketID: 100, Entries: 2, Billable: 90 * time.Minute, NonBillable: 45 * time.Minute},
+		{AgentID: 8, DepartmentID: 3, TicketID: 100, Entries: 2, Billable: 2 * time.Hour, NonBillable: 15 * time.Minute},
+	}
+	if got := sheet.Rows(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Rows() = %+v, want %+v", got, want)
+	}
+	if got, want := sheet.ByAgent(), map[int64]time.Duration{7: 165 * time.Minute, 8: 135 * time.Minute}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ByAgent() = %v, want %v", got, want)
+	}
+	if got, want := sheet.ByDepartment(), map[int64]time.Duration{0: 30 * time.Minute, 3: 270 * time.Minute}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ByDepartment() = %v, want %v", got, want)
+	}
+	if got, want := sheet.ByTicket(), map[int64]time.Duration{100: 270 * time.Minute, 101: 30 * time.Minute}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ByTicket() = %v, want %v", got, want)
+	}
+}
+
+func TestTimesheetAddReplaces(t *testing.T) {
+	sheet := testTimesheet(t)
+	// The ticket was moved to another department and agent 8's entries removed.
+	if err := sheet.Add(Ticket{ID: 100, DepartmentID: 4}, TimeEntrySlice{
+		timeEntry(1, 7, 0, "01:30", true),
+		timeEntry(2, 7, 1, "00:45", false),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	want := []TimesheetRow{
+		{AgentID: 7, DepartmentID: 0, TicketID: 101, Entries: 1, Billable: 30 * time.Minute},
+		{AgentID: 7, DepartmentID: 4, TicketID: 100, Entries: 2, Billable: 90 * time.Minute, NonBillable: 45 * time.Minute},
+	}
+	if got := sheet.Rows(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Rows() after adding the ticket again = %+v, want %+v", got, want)
+	}
+}
+
+func TestTimesheetAddError(t *testing.T) {
+	sheet := testTimesheet(t)
+	before := sheet.Rows()
+	err := sheet.Add(Ticket{ID: 100}, TimeEntrySlice{
+		timeEntry(1, 7, 0, "01:30", true),
+		timeEntry(9, 7, 0, "an hour", true),
+	})
+	if err == nil || err.Error() != `ticket 100 time entry 9: invalid time spent "an hour"` {
+		t.Errorf("Add() error = %v", err)
+	}
+	if got := sheet.Rows(); !reflect.DeepEqual(got, before) {
+		t.Errorf("failed Add changed the rows to %+v", got)
+	}
+}
+
+func TestTimesheetWriteCSV(t *testing.T) {
+	buf := bytes.Buffer{}
+	if err := testTimesheet(t).WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "agent_id,agent,department_id,department,ticket_id,entries,billable_hours,non_billable_hours,total_hours\n" +
+		"7,Jane Doe,0,,101,1,0.50,0.00,0.50\n" +
+		"7,Jane Doe,3,\"Finance, Legal\",100,2,1.50,0.75,2.25\n" +
+		"8,,3,\"Finance, Legal\",100,2,2.00,0.25,2.25\n"
+	if got := buf.String(); got != want {
+		t.Errorf("WriteCSV() =\n%s\nwant\n%s", got, want)
+	}
+}
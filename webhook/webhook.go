@@ -0,0 +1,295 @@
+// Package webhook receives the JSON requests sent by Freshservice Workflow
+// Automator webhook actions.
+//
+// The webhook body is expected to carry the event type, an optional event ID
+// and the ticket placeholders, for example:
+//
+//	{
+//		"id": "{{ticket.id_numeric}}-{{ticket.updated_at}}",
+//		"event": "ticket_updated",
+//		"ticket": {
+//			"id": {{ticket.id_numeric}},
+//			"subject": "{{ticket.subject}}",
+//			"status": "{{ticket.status}}",
+//			"priority": "{{ticket.priority}}"
+//		}
+//	}
+//
+// Status, priority and source accept either their number or their name.
+// Freshservice renders these placeholders as names, so custom statuses arrive
+// as names the package does not know: they are left zero in Event.Ticket and
+// kept in Event.Unresolved, to be resolved with the account's TicketMetadata.
+package webhook
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	freshservice "github.com/nextlinktechnology/go-freshservice"
+)
+
+// EventType is the "event" of a webhook payload, chosen when configuring the
+// webhook in the workflow.
+type EventType string
+
+const (
+	TicketCreated       EventType = "ticket_created"
+	TicketUpdated       EventType = "ticket_updated"
+	TicketStatusChanged EventType = "ticket_status_changed"
+	TicketAssigned      EventType = "ticket_assigned"
+	TicketClosed        EventType = "ticket_closed"
+)
+
+// DefaultSecretHeader is the header checked by WithSecret when none is given.
+const DefaultSecretHeader = "X-Freshservice-Secret"
+
+const maxBodySize = 1 << 20
+
+// Event is a decoded webhook payload. Raw holds the whole body, for fields
+// that are not part of Ticket.
+type Event struct {
+	ID     string              `json:"id"`
+	Type   EventType           `json:"event"`
+	Ticket freshservice.Ticket `json:"ticket"`
+	Raw    json.RawMessage     `json:"-"`
+	// Unresolved holds the status, priority and source names, keyed by
+	// field, that are not built in, e.g. {"status": "Awaiting vendor"}.
+	Unresolved map[string]string `json:"-"`
+}
+
+// choiceFields parse the ticket fields that are sent as names.
+var choiceFields = map[string]func(string) error{
+	"status":   func(text string) error { _, err := freshservice.ParseStatus(text); return err },
+	"priority": func(text string) error { _, err := freshservice.ParsePriority(text); return err },
+	"source":   func(text string) error { _, err := freshservice.ParseSource(text); return err },
+}
+
+// decodeEvent decodes the payload, moving unknown status, priority and source
+// names to Unresolved instead of failing on them.
+func decodeEvent(body []byte) (Event, error) {
+	payload := struct {
+		ID     string                     `json:"id"`
+		Type   EventType                  `json:"event"`
+		Ticket map[string]json.RawMessage `json:"ticket"`
+	}{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return Event{}, err
+	}
+	event := Event{ID: payload.ID, Type: payload.Type, Raw: body}
+	for field, parse := range choiceFields {
+		var name string
+		if json.Unmarshal(payload.Ticket[field], &name) != nil || parse(name) == nil {
+			continue
+		}
+		if event.Unresolved == nil {
+			event.Unresolved = map[string]string{}
+		}
+		event.Unresolved[field] = name
+		delete(payload.Ticket, field)
+	}
+	if payload.Ticket == nil {
+		return event, nil
+	}
+	ticket, err := json.Marshal(payload.Ticket)
+	if err != nil {
+		return Event{}, err
+	}
+	return event, json.Unmarshal(ticket, &event.Ticket)
+}
+
+// HandlerFunc processes an event. Returning an error answers the request
+// with 500 so that Freshservice retries it.
+type HandlerFunc func(*http.Request, Event) error
+
+// Handler is an http.Handler that authenticates webhook requests, drops
+// retries of events it already processed and dispatches the others to the
+// handler registered for their type.
+//
+// Responses are 204 once the event is processed, ignored or a duplicate, 401
+// for failed authentication or when no credential is configured, 405 for
+// other methods than POST, 413 for bodies above 1 MiB, 400 for unreadable or
+// undecodable payloads and 500 for handler errors.
+type Handler struct {
+	secretHeader string
+	secret       string
+	username     string
+	password     string
+	dedupeTTL    time.Duration
+	handlers     map[EventType]HandlerFunc
+	fallback     HandlerFunc
+
+	mutex  sync.Mutex
+	seen   map[string]time.Time
+	expiry []seenEvent
+}
+
+// seenEvent is an entry of the queue of remembered events, in claim order.
+type seenEvent struct {
+	key     string
+	expires time.Time
+}
+
+// NewHandler returns a handler remembering events for an hour to drop retries.
+// It rejects every request until WithSecret or WithBasicAuth is called.
+func NewHandler() *Handler {
+	return &Handler{
+		dedupeTTL: time.Hour,
+		handlers:  map[EventType]HandlerFunc{},
+		seen:      map[string]time.Time{},
+	}
+}
+
+// WithSecret requires the header to hold the shared secret. An empty header
+// uses DefaultSecretHeader.
+func (handler *Handler) WithSecret(header, secret string) *Handler {
+	if header == "" {
+		header = DefaultSecretHeader
+	}
+	handler.secretHeader = header
+	handler.secret = secret
+	return handler
+}
+
+// WithBasicAuth requires the request to carry these basic auth credentials.
+func (handler *Handler) WithBasicAuth(username, password string) *Handler {
+	handler.username = username
+	handler.password = password
+	return handler
+}
+
+// WithDedupeTTL sets how long event IDs are remembered to drop retries.
+func (handler *Handler) WithDedupeTTL(ttl time.Duration) *Handler {
+	handler.dedupeTTL = ttl
+	return handler
+}
+
+// Handle registers the function processing events of the type.
+func (handler *Handler) Handle(eventType EventType, fn HandlerFunc) *Handler {
+	handler.handlers[eventType] = fn
+	return handler
+}
+
+// HandleDefault registers the function processing events without a
+// registered handler. Without one such events are acknowledged and dropped.
+func (handler *Handler) HandleDefault(fn HandlerFunc) *Handler {
+	handler.fallback = fn
+	return handler
+}
+
+func (handler *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if !handler.authenticated(r) {
+		if handler.username != "" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="freshservice"`)
+		}
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	if err != nil {
+		http.Error(w, "unreadable body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxBodySize {
+		http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+		return
+	}
+	event, err := decodeEvent(body)
+	if err != nil {
+		http.Error(w, "invalid payload: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if event.Type == "" {
+		http.Error(w, "invalid payload: missing event", http.StatusBadRequest)
+		return
+	}
+
+	fn, ok := handler.handlers[event.Type]
+	if !ok {
+		fn = handler.fallback
+	}
+	if fn == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	key := event.ID
+	if key == "" {
+		sum := sha256.Sum256(body)
+		key = hex.EncodeToString(sum[:])
+	}
+	if !handler.claim(key) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if err := fn(r, event); err != nil {
+		handler.release(key)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authenticated checks every configured credential in constant time. A
+// handler without credentials rejects every request.
+func (handler *Handler) authenticated(r *http.Request) bool {
+	if handler.secret == "" && handler.username == "" {
+		return false
+	}
+	if handler.secret != "" && !equal(r.Header.Get(handler.secretHeader), handler.secret) {
+		return false
+	}
+	if handler.username != "" {
+		username, password, ok := r.BasicAuth()
+		if !ok || !equal(username, handler.username) || !equal(password, handler.password) {
+			return false
+		}
+	}
+	return true
+}
+
+func equal(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// claim records the event as processed, unless it already was or is being
+// processed by a concurrent retry. Expired events are dropped from the front
+// of the queue, which is ordered by expiry as long as the TTL is unchanged.
+func (handler *Handler) claim(key string) bool {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	now := time.Now()
+	for len(handler.expiry) > 0 && now.After(handler.expiry[0].expires) {
+		oldest := handler.expiry[0]
+		handler.expiry = handler.expiry[1:]
+		if expires, ok := handler.seen[oldest.key]; ok && expires.Equal(oldest.expires) {
+			delete(handler.seen, oldest.key)
+		}
+	}
+	if expires, ok := handler.seen[key]; ok && !now.After(expires) {
+		return false
+	}
+	expires := now.Add(handler.dedupeTTL)
+	handler.seen[key] = expires
+	handler.expiry = append(handler.expiry, seenEvent{key, expires})
+	return true
+}
+
+// release forgets a failed event so that its retry is processed.
+func (handler *Handler) release(key string) {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	delete(handler.seen, key)
+}
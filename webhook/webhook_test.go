@@ -0,0 +1,239 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	freshservice "github.com/nextlinktechnology/go-freshservice"
+)
+
+const payload = `{"id":"42-1","event":"ticket_updated","ticket":{"id":42,"subject":"Printer","status":"Open","priority":3}}`
+
+func serve(handler http.Handler, method, body string, prepare func(*http.Request)) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, "/webhook", strings.NewReader(body))
+	if prepare != nil {
+		prepare(r)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	return w
+}
+
+func withSecret(secret string) func(*http.Request) {
+	return func(r *http.Request) { r.Header.Set(DefaultSecretHeader, secret) }
+}
+
+func TestAuthentication(t *testing.T) {
+	ok := func(*http.Request, Event) error { return nil }
+	secret := NewHandler().WithSecret("", "s3cret").Handle(TicketUpdated, ok)
+	custom := NewHandler().WithSecret("X-Token", "s3cret").Handle(TicketUpdated, ok)
+	basic := NewHandler().WithBasicAuth("fresh", "pa55").Handle(TicketUpdated, ok)
+	both := NewHandler().WithSecret("", "s3cret").WithBasicAuth("fresh", "pa55").Handle(TicketUpdated, ok)
+	none := NewHandler().Handle(TicketUpdated, ok)
+
+	tests := []struct {
+		name    string
+		handler *Handler
+		prepare func(*http.Request)
+		want    int
+	}{
+		{"secret", secret, withSecret("s3cret"), http.StatusNoContent},
+		{"wrong secret", secret, withSecret("guess"), http.StatusUnauthorized},
+		{"missing secret", secret, nil, http.StatusUnauthorized},
+		{"custom header", custom, func(r *http.Request) { r.Header.Set("X-Token", "s3cret") }, http.StatusNoContent},
+		{"custom header ignores default", custom, withSecret("s3cret"), http.StatusUnauthorized},
+		{"basic auth", basic, func(r *http.Request) { r.SetBasicAuth("fresh", "pa55") }, http.StatusNoContent},
+		{"wrong password", basic, func(r *http.Request) { r.SetBasicAuth("fresh", "guess") }, http.StatusUnauthorized},
+		{"missing basic auth", basic, nil, http.StatusUnauthorized},
+		{"both", both, func(r *http.Request) { r.SetBasicAuth("fresh", "pa55"); withSecret("s3cret")(r) }, http.StatusNoContent},
+		{"both without secret", both, func(r *http.Request) { r.SetBasicAuth("fresh", "pa55") }, http.StatusUnauthorized},
+		{"no credentials configured", none, nil, http.StatusUnauthorized},
+	}
+	for _, test := range tests {
+		w := serve(test.handler, http.MethodPost, payload, test.prepare)
+		if w.Code != test.want {
+			t.Errorf("%s: status = %d, want %d", test.name, w.Code, test.want)
+		}
+	}
+
+	w := serve(basic, http.MethodPost, payload, nil)
+	if w.Header().Get("WWW-Authenticate") == "" {
+		t.Error("basic auth challenge missing")
+	}
+}
+
+func TestRequestErrors(t *testing.T) {
+	handler := NewHandler().WithSecret("", "s3cret").Handle(TicketUpdated, func(*http.Request, Event) error { return nil })
+	large := `{"event":"ticket_updated","pad":"` + strings.Repeat("x", maxBodySize) + `"}`
+
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		want   int
+	}{
+		{"get", http.MethodGet, "", http.StatusMethodNotAllowed},
+		{"too large", http.MethodPost, large, http.StatusRequestEntityTooLarge},
+		{"invalid json", http.MethodPost, `{"event":`, http.StatusBadRequest},
+		{"missing event", http.MethodPost, `{"ticket":{"id":1}}`, http.StatusBadRequest},
+		{"invalid status", http.MethodPost, `{"event":"ticket_updated","ticket":{"status":true}}`, http.StatusBadRequest},
+		{"invalid ticket", http.MethodPost, `{"event":"ticket_updated","ticket":[]}`, http.StatusBadRequest},
+		{"unregistered event", http.MethodPost, `{"event":"asset_created"}`, http.StatusNoContent},
+	}
+	for _, test := range tests {
+		w := serve(handler, test.method, test.body, withSecret("s3cret"))
+		if w.Code != test.want {
+			t.Errorf("%s: status = %d, want %d", test.name, w.Code, test.want)
+		}
+	}
+
+	w := serve(handler, http.MethodGet, "", withSecret("s3cret"))
+	if w.Header().Get("Allow") != http.MethodPost {
+		t.Errorf("Allow = %q", w.Header().Get("Allow"))
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("connection reset") }
+
+func TestUnreadableBody(t *testing.T) {
+	handler := NewHandler().WithSecret("", "s3cret")
+	r := httptest.NewRequest(http.MethodPost, "/webhook", failingReader{})
+	withSecret("s3cret")(r)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	var got []Event
+	handler := NewHandler().WithSecret("", "s3cret").
+		Handle(TicketUpdated, func(r *http.Request, event Event) error {
+			got = append(got, event)
+			return nil
+		}).
+		HandleDefault(func(r *http.Request, event Event) error {
+			got = append(got, Event{Type: "default:" + event.Type})
+			return nil
+		})
+
+	if w := serve(handler, http.MethodPost, payload, withSecret("s3cret")); w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d", w.Code)
+	}
+	if w := serve(handler, http.MethodPost, `{"event":"ticket_closed"}`, withSecret("s3cret")); w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d", w.Code)
+	}
+	if len(got) != 2 {
+		t.Fatalf("events = %+v", got)
+	}
+	event := got[0]
+	if event.ID != "42-1" || event.Ticket.ID != 42 || event.Ticket.Status != freshservice.StatusOpen ||
+		event.Ticket.Priority != freshservice.PriorityHigh || string(event.Raw) != payload {
+		t.Errorf("event = %+v", event)
+	}
+	if got[1].Type != "default:ticket_closed" {
+		t.Errorf("default handler got %q", got[1].Type)
+	}
+}
+
+func TestCustomChoiceNames(t *testing.T) {
+	var got Event
+	handler := NewHandler().WithSecret("", "s3cret").Handle(TicketUpdated, func(r *http.Request, event Event) error {
+		got = event
+		return nil
+	})
+	body := `{"event":"ticket_updated","ticket":{"id":9,"status":"Awaiting vendor","priority":"High","source":"Teams"}}`
+	if w := serve(handler, http.MethodPost, body, withSecret("s3cret")); w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want 204", w.Code)
+	}
+	if got.Ticket.ID != 9 || got.Ticket.Status != 0 || got.Ticket.Priority != freshservice.PriorityHigh || got.Ticket.Source != 0 {
+		t.Errorf("ticket = %+v", got.Ticket)
+	}
+	want := map[string]string{"status": "Awaiting vendor", "source": "Teams"}
+	if len(got.Unresolved) != len(want) || got.Unresolved["status"] != want["status"] || got.Unresolved["source"] != want["source"] {
+		t.Errorf("Unresolved = %v, want %v", got.Unresolved, want)
+	}
+
+	got = Event{}
+	serve(handler, http.MethodPost, `{"id":"x","event":"ticket_updated","ticket":{"status":6}}`, withSecret("s3cret"))
+	if got.Ticket.Status != 6 || got.Unresolved != nil {
+		t.Errorf("numeric custom status: ticket = %+v, unresolved = %v", got.Ticket, got.Unresolved)
+	}
+}
+
+func TestClaimExpiry(t *testing.T) {
+	handler := NewHandler()
+	if !handler.claim("a") || handler.claim("a") {
+		t.Fatal("claim() did not drop the duplicate")
+	}
+	handler.WithDedupeTTL(-time.Second)
+	if !handler.claim("b") || !handler.claim("b") {
+		t.Error("expired event claimed as duplicate")
+	}
+	// "a" is still remembered, so the expired "b" entries behind it stay
+	// queued until it expires.
+	if len(handler.seen) != 2 || len(handler.expiry) != 3 {
+		t.Errorf("seen = %v, queue = %v", handler.seen, handler.expiry)
+	}
+
+	handler = NewHandler().WithDedupeTTL(-time.Second)
+	for _, key := range []string{"a", "b", "c"} {
+		handler.claim(key)
+	}
+	if len(handler.seen) != 1 || len(handler.expiry) != 1 {
+		t.Errorf("expired events kept: seen = %v, queue = %v", handler.seen, handler.expiry)
+	}
+	handler.release("c")
+	if len(handler.seen) != 0 {
+		t.Errorf("released event kept: %v", handler.seen)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	calls := 0
+	fail := true
+	handler := NewHandler().WithSecret("", "s3cret").Handle(TicketUpdated, func(*http.Request, Event) error {
+		calls++
+		if fail {
+			return errors.New("database down")
+		}
+		return nil
+	})
+
+	if w := serve(handler, http.MethodPost, payload, withSecret("s3cret")); w.Code != http.StatusInternalServerError {
+		t.Fatalf("failing handler: status = %d, want 500", w.Code)
+	}
+	fail = false
+	if w := serve(handler, http.MethodPost, payload, withSecret("s3cret")); w.Code != http.StatusNoContent {
+		t.Fatalf("retry after failure: status = %d, want 204", w.Code)
+	}
+	if w := serve(handler, http.MethodPost, payload, withSecret("s3cret")); w.Code != http.StatusNoContent {
+		t.Fatalf("duplicate retry: status = %d, want 204", w.Code)
+	}
+	if calls != 2 {
+		t.Errorf("handler called %d times, want 2", calls)
+	}
+
+	// Without an event ID, identical bodies are duplicates.
+	body := `{"event":"ticket_updated","ticket":{"id":7}}`
+	serve(handler, http.MethodPost, body, withSecret("s3cret"))
+	serve(handler, http.MethodPost, body, withSecret("s3cret"))
+	if calls != 3 {
+		t.Errorf("handler called %d times, want 3", calls)
+	}
+
+	// Remembered events expire.
+	handler.WithDedupeTTL(-1)
+	serve(handler, http.MethodPost, `{"id":"a","event":"ticket_updated"}`, withSecret("s3cret"))
+	serve(handler, http.MethodPost, `{"id":"a","event":"ticket_updated"}`, withSecret("s3cret"))
+	if calls != 5 {
+		t.Errorf("handler called %d times, want 5", calls)
+	}
+}